@@ -12,8 +12,14 @@ import (
 
 	"api-gateway/config"
 	"api-gateway/internal/handlers"
+	"api-gateway/internal/httpclient"
 	"api-gateway/internal/kafka"
+	"api-gateway/internal/magiclink"
+	"api-gateway/internal/openapi"
+	"api-gateway/internal/ratelimit"
+	"api-gateway/internal/readmodel"
 	"api-gateway/internal/routes"
+	"api-gateway/internal/subscriptionclient"
 	"api-gateway/internal/weatherclient"
 
 	"github.com/go-chi/chi/v5"
@@ -39,16 +45,56 @@ func Run() error {
 	}()
 
 	subscribeHandler := handlers.NewSubscribeHandler(publisher)
+	subscriptionAPIHandler := handlers.NewSubscriptionAPIHandler(publisher)
 
 	weatherClient, err := weatherclient.New(cfg.WeatherServiceAddr)
 	if err != nil {
 		return fmt.Errorf("failed to init weather client: %w", err)
 	}
 
-	weatherHandler := handlers.NewWeatherHandler(weatherClient)
+	weatherReadModel := readmodel.NewWeatherStore(cfg.WeatherReadModelTTL)
+	consumerCtx, stopConsumer := context.WithCancel(context.Background())
+	defer stopConsumer()
+	weatherConsumer := kafka.NewConsumer(cfg.KafkaBrokers, cfg.WeatherEventsTopic, weatherReadModel.HandleWeatherUpdated)
+	consumerDone := weatherConsumer.Start(consumerCtx)
+
+	weatherHandler := handlers.NewWeatherHandler(weatherClient, weatherReadModel)
+
+	subscriptionClient := subscriptionclient.New(httpclient.New(), cfg.SubscriptionServiceURL)
+	requestStatusHandler := handlers.NewRequestStatusHandler(subscriptionClient)
+
+	manageHandler := handlers.NewManageHandler(publisher, subscriptionClient, nil, nil, cfg.ManagePageURL)
+	if cfg.ManageLinkSecret != "" {
+		signer := magiclink.NewSigner(cfg.ManageLinkSecret, cfg.ManageLinkTTL)
+		var limiter *ratelimit.Limiter
+		if cfg.ManageLinkRateLimit > 0 {
+			limiter = ratelimit.NewLimiter(cfg.ManageLinkRateLimit, cfg.ManageLinkRateWindow)
+		}
+		manageHandler = handlers.NewManageHandler(publisher, subscriptionClient, signer, limiter, cfg.ManagePageURL)
+	}
+
+	telegramHandler := handlers.NewTelegramHandler(publisher, cfg.TelegramWebhookSecret)
+
+	spec, err := openapi.Load()
+	if err != nil {
+		return err
+	}
+	validator, err := openapi.NewValidator(spec)
+	if err != nil {
+		return err
+	}
 
 	r.Route("/api", func(r chi.Router) {
-		routes.RegisterRoutes(r, weatherHandler, subscribeHandler)
+		r.Use(validator.Middleware)
+		routes.RegisterRoutes(
+			r,
+			weatherHandler,
+			subscribeHandler,
+			subscriptionAPIHandler,
+			manageHandler,
+			requestStatusHandler,
+			telegramHandler,
+		)
 	})
 
 	addr := ":" + cfg.Port
@@ -75,5 +121,12 @@ func Run() error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
 	defer cancel()
 
+	stopConsumer()
+	select {
+	case <-consumerDone:
+	case <-ctx.Done():
+		log.Printf("weather consumer shutdown timeout: %v", ctx.Err())
+	}
+
 	return srv.Shutdown(ctx)
 }
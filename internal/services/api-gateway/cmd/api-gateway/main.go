@@ -3,6 +3,7 @@ package main
 import (
 	"api-gateway/app"
 	"log"
+	_ "time/tzdata"
 )
 
 func main() {
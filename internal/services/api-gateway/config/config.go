@@ -2,15 +2,36 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 )
 
 type Config struct {
-	Port               string   `envconfig:"PORT" default:"8084"`
-	KafkaBrokers       []string `envconfig:"KAFKA_BROKERS" required:"true"`
-	KafkaTopic         string   `envconfig:"KAFKA_TOPIC" required:"true"`
-	WeatherServiceAddr string   `envconfig:"WEATHER_SERVICE_ADDR" default:"weather-service:8081"`
+	Port                   string   `envconfig:"PORT" default:"8084"`
+	KafkaBrokers           []string `envconfig:"KAFKA_BROKERS" required:"true"`
+	KafkaTopic             string   `envconfig:"KAFKA_TOPIC" required:"true"`
+	WeatherServiceAddr     string   `envconfig:"WEATHER_SERVICE_ADDR" default:"weather-service:8081"`
+	SubscriptionServiceURL string   `envconfig:"SUBSCRIPTION_SERVICE_URL" default:"http://subscription-service:8428"`
+
+	WeatherEventsTopic  string        `envconfig:"KAFKA_WEATHER_CITY_UPDATED_TOPIC" default:"weather.city_updated"`
+	WeatherReadModelTTL time.Duration `envconfig:"WEATHER_READ_MODEL_TTL" default:"10m"`
+
+	// TelegramWebhookSecret must match the secret_token given to setWebhook;
+	// the webhook endpoint is disabled while it is empty.
+	TelegramWebhookSecret string `envconfig:"TELEGRAM_WEBHOOK_SECRET"`
+
+	// ManageLinkSecret signs manage-portal links; the portal endpoints are
+	// disabled while it is empty. ManagePageURL is the public/manage.html page
+	// the mailed link points to.
+	ManageLinkSecret string        `envconfig:"MANAGE_LINK_SECRET"`
+	ManageLinkTTL    time.Duration `envconfig:"MANAGE_LINK_TTL" default:"15m"`
+	ManagePageURL    string        `envconfig:"MANAGE_PAGE_URL" default:"http://localhost:8080/manage.html"`
+
+	// ManageLinkRateLimit caps the manage links one address may request per
+	// ManageLinkRateWindow; zero disables the limit.
+	ManageLinkRateLimit  int           `envconfig:"MANAGE_LINK_RATE_LIMIT" default:"3"`
+	ManageLinkRateWindow time.Duration `envconfig:"MANAGE_LINK_RATE_WINDOW" default:"1h"`
 }
 
 func Load() (Config, error) {
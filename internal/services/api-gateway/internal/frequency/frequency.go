@@ -0,0 +1,154 @@
+// Package frequency parses the subscription frequencies accepted by the
+// gateway. It mirrors the schedule rules subscription-service enforces, so
+// invalid schedules are rejected before a command is published.
+package frequency
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Hourly   = "hourly"
+	Daily    = "daily"
+	Weekdays = "weekdays"
+
+	minutesPerHour     = 60
+	minutesPerDay      = 24 * minutesPerHour
+	deliveryTimeLayout = "15:04"
+	weekdaysDays       = "Mon-Fri"
+)
+
+// ErrInvalid lists the accepted forms; it is returned for unrecognised input.
+var ErrInvalid = errors.New(
+	"invalid frequency: use 'hourly', 'daily', 'weekdays', 'every Nh' (N = 1, 2, 3, 4, 6, 8 or 12) " +
+		"or 'HH:MM <days>' such as '07:30 Mon-Fri'")
+
+var dayNames = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// Spec is a parsed frequency: an interval of Minutes, optionally delivered at
+// DeliveryTime on Days (daily only).
+type Spec struct {
+	Minutes      int
+	DeliveryTime string
+	Days         string
+}
+
+// Parse reads frequency together with the separate delivery_time field. The
+// accepted forms are "hourly", "daily", "every Nh", "weekdays" (needs a
+// delivery time) and "HH:MM <days>", whose time may not contradict
+// deliveryTime.
+func Parse(frequency, deliveryTime string) (Spec, error) {
+	frequency = strings.TrimSpace(frequency)
+	if deliveryTime != "" {
+		if _, err := time.Parse(deliveryTimeLayout, deliveryTime); err != nil {
+			return Spec{}, fmt.Errorf("invalid delivery_time: expected HH:MM")
+		}
+	}
+
+	var spec Spec
+	switch {
+	case frequency == Hourly:
+		spec = Spec{Minutes: minutesPerHour}
+	case frequency == Daily:
+		return Spec{Minutes: minutesPerDay, DeliveryTime: deliveryTime}, nil
+	case frequency == Weekdays:
+		if deliveryTime == "" {
+			return Spec{}, fmt.Errorf("delivery_time is required for weekdays frequency")
+		}
+		return Spec{Minutes: minutesPerDay, DeliveryTime: deliveryTime, Days: weekdaysDays}, nil
+	case strings.HasPrefix(frequency, "every "):
+		hours, err := parseInterval(strings.TrimPrefix(frequency, "every "))
+		if err != nil {
+			return Spec{}, err
+		}
+		spec = Spec{Minutes: hours * minutesPerHour}
+	default:
+		return parseExpression(frequency, deliveryTime)
+	}
+	if deliveryTime != "" {
+		return Spec{}, fmt.Errorf("delivery_time is only supported for daily frequency")
+	}
+	return spec, nil
+}
+
+// Format renders a stored schedule in the form Parse accepts.
+func Format(minutes int, deliveryTime, days string) string {
+	switch {
+	case days == weekdaysDays:
+		return Weekdays
+	case days != "":
+		return deliveryTime + " " + days
+	case minutes == minutesPerHour:
+		return Hourly
+	case minutes == minutesPerDay:
+		return Daily
+	case minutes%minutesPerHour == 0:
+		return fmt.Sprintf("every %dh", minutes/minutesPerHour)
+	default:
+		return fmt.Sprintf("every %dm", minutes)
+	}
+}
+
+// parseInterval reads "Nh" where N hours divide a day evenly, so slots stay
+// aligned from one day to the next.
+func parseInterval(value string) (int, error) {
+	hours, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(value), "h"))
+	if err != nil || !strings.HasSuffix(value, "h") || hours <= 0 || hours >= 24 || 24%hours != 0 {
+		return 0, ErrInvalid
+	}
+	return hours, nil
+}
+
+func parseExpression(expression, deliveryTime string) (Spec, error) {
+	at, days, ok := strings.Cut(expression, " ")
+	if !ok {
+		return Spec{}, ErrInvalid
+	}
+	if _, err := time.Parse(deliveryTimeLayout, at); err != nil {
+		return Spec{}, ErrInvalid
+	}
+	if deliveryTime != "" && deliveryTime != at {
+		return Spec{}, fmt.Errorf("delivery_time %s contradicts the time in frequency %q", deliveryTime, expression)
+	}
+	canonical, err := canonicalDays(strings.TrimSpace(days))
+	if err != nil {
+		return Spec{}, err
+	}
+	return Spec{Minutes: minutesPerDay, DeliveryTime: at, Days: canonical}, nil
+}
+
+// canonicalDays validates a comma-separated list of day names and ranges
+// ("Mon-Fri", "Sat,Sun", wrapping "Fri-Mon") and normalises their case; the
+// keywords above are lowercase only, like the OpenAPI pattern.
+func canonicalDays(spec string) (string, error) {
+	items := strings.Split(spec, ",")
+	for i, item := range items {
+		from, to, isRange := strings.Cut(strings.TrimSpace(item), "-")
+		first, err := canonicalDay(from, spec)
+		if err != nil {
+			return "", err
+		}
+		items[i] = first
+		if isRange {
+			last, err := canonicalDay(to, spec)
+			if err != nil {
+				return "", err
+			}
+			items[i] += "-" + last
+		}
+	}
+	return strings.Join(items, ","), nil
+}
+
+func canonicalDay(name, spec string) (string, error) {
+	for _, day := range dayNames {
+		if strings.EqualFold(name, day) {
+			return day, nil
+		}
+	}
+	return "", fmt.Errorf("invalid days %q: unknown day %q, use Mon, Tue, Wed, Thu, Fri, Sat or Sun", spec, name)
+}
@@ -0,0 +1,80 @@
+package frequency_test
+
+import (
+	"testing"
+
+	"api-gateway/internal/frequency"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		frequency    string
+		deliveryTime string
+		want         frequency.Spec
+	}{
+		{"hourly", "", frequency.Spec{Minutes: 60}},
+		{"daily", "", frequency.Spec{Minutes: 1440}},
+		{"daily", "08:00", frequency.Spec{Minutes: 1440, DeliveryTime: "08:00"}},
+		{"every 3h", "", frequency.Spec{Minutes: 180}},
+		{"every 12h", "", frequency.Spec{Minutes: 720}},
+		{"weekdays", "07:30", frequency.Spec{Minutes: 1440, DeliveryTime: "07:30", Days: "Mon-Fri"}},
+		{"07:30 Mon-Fri", "", frequency.Spec{Minutes: 1440, DeliveryTime: "07:30", Days: "Mon-Fri"}},
+		{"09:00 sat,SUN", "09:00", frequency.Spec{Minutes: 1440, DeliveryTime: "09:00", Days: "Sat,Sun"}},
+		{"18:00 Fri-Mon", "", frequency.Spec{Minutes: 1440, DeliveryTime: "18:00", Days: "Fri-Mon"}},
+	} {
+		t.Run(tc.frequency, func(t *testing.T) {
+			got, err := frequency.Parse(tc.frequency, tc.deliveryTime)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Parse() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParse_Rejects(t *testing.T) {
+	for _, tc := range []struct {
+		frequency    string
+		deliveryTime string
+	}{
+		{"", ""},
+		{"weekly", ""},
+		{"Daily", ""},
+		{"every 5h", ""},
+		{"every 24h", ""},
+		{"every 0h", ""},
+		{"every 3", ""},
+		{"hourly", "08:00"},
+		{"every 6h", "08:00"},
+		{"weekdays", ""},
+		{"daily", "8am"},
+		{"07:30 Mon-Fry", ""},
+		{"07:30", ""},
+		{"25:00 Mon", ""},
+		{"07:30 Mon-Fri", "08:00"},
+	} {
+		t.Run(tc.frequency+"/"+tc.deliveryTime, func(t *testing.T) {
+			if _, err := frequency.Parse(tc.frequency, tc.deliveryTime); err == nil {
+				t.Errorf("Parse(%q, %q) succeeded, want error", tc.frequency, tc.deliveryTime)
+			}
+		})
+	}
+}
+
+func TestFormat_RoundTrips(t *testing.T) {
+	for _, in := range []string{"hourly", "daily", "every 6h", "07:30 Mon-Fri", "09:00 Sat,Sun"} {
+		spec, err := frequency.Parse(in, "")
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", in, err)
+		}
+		want := in
+		if in == "07:30 Mon-Fri" {
+			want = frequency.Weekdays
+		}
+		if got := frequency.Format(spec.Minutes, spec.DeliveryTime, spec.Days); got != want {
+			t.Errorf("Format(%+v) = %q, want %q", spec, got, want)
+		}
+	}
+}
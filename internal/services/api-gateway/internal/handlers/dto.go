@@ -1,11 +1,85 @@
 package handlers
 
+import "time"
+
 type SubscriptionCommand struct {
+	// CommandID is the idempotency key the subscription service uses to
+	// recognise redelivered commands.
+	CommandID        string `json:"command_id"`
+	RequestID        string `json:"request_id"`
 	Command          string `json:"command"`
 	ChannelType      string `json:"channel_type,omitempty"`
 	ChannelValue     string `json:"channel_value,omitempty"`
 	City             string `json:"city,omitempty"`
 	Frequency        string `json:"frequency,omitempty"`
 	FrequencyMinutes int    `json:"frequency_minutes,omitempty"`
+	DeliveryTime     string `json:"delivery_time,omitempty"`
+	DeliveryDays     string `json:"delivery_days,omitempty"`
+	Timezone         string `json:"timezone,omitempty"`
 	Token            string `json:"token,omitempty"`
-}
\ No newline at end of file
+	Link             string `json:"link,omitempty"`
+	LinkExpiresAt    int64  `json:"link_expires_at,omitempty"`
+}
+
+// SubscriptionRequest is the JSON body of POST /api/v1/subscriptions; the
+// fields match the form fields of POST /api/subscribe. Only the recipient
+// field of the chosen channel is used.
+type SubscriptionRequest struct {
+	Channel      string `json:"channel"`
+	Email        string `json:"email,omitempty"`
+	Phone        string `json:"phone,omitempty"`
+	URL          string `json:"url,omitempty"`
+	City         string `json:"city"`
+	Frequency    string `json:"frequency"`
+	DeliveryTime string `json:"delivery_time,omitempty"`
+	Timezone     string `json:"timezone,omitempty"`
+}
+
+// ScheduleRequest is the JSON body of PATCH /api/v1/subscriptions/{token}.
+// An empty timezone keeps the subscription's current one.
+type ScheduleRequest struct {
+	Frequency    string `json:"frequency"`
+	DeliveryTime string `json:"delivery_time,omitempty"`
+	Timezone     string `json:"timezone,omitempty"`
+}
+
+// ManageLinkRequest is the JSON body of POST /api/v1/manage/link.
+type ManageLinkRequest struct {
+	Email string `json:"email"`
+}
+
+// ManagedSubscription is one subscription listed in the manage portal. ID
+// addresses it in the /api/v1/manage/subscriptions/{id} endpoints, which
+// accept it only together with a valid manage link, so the permanent
+// management token never leaves the gateway.
+type ManagedSubscription struct {
+	ID           string    `json:"id"`
+	City         string    `json:"city"`
+	Frequency    string    `json:"frequency"`
+	DeliveryTime string    `json:"delivery_time,omitempty"`
+	Timezone     string    `json:"timezone"`
+	Confirmed    bool      `json:"confirmed"`
+	Paused       bool      `json:"paused"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ManagedSubscriptionsResponse struct {
+	Email         string                `json:"email"`
+	Subscriptions []ManagedSubscription `json:"subscriptions"`
+}
+
+// ErrorResponse is the body of every /api/v1 error.
+type ErrorResponse struct {
+	Error APIError `json:"error"`
+}
+
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type CommandAcceptedResponse struct {
+	RequestID string `json:"request_id"`
+	StatusURL string `json:"status_url"`
+	Message   string `json:"message"`
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"api-gateway/internal/frequency"
+	"api-gateway/internal/magiclink"
+	"api-gateway/internal/subscriptionclient"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type manageTokenManager interface {
+	Issue(subject string) (string, time.Time, error)
+	Verify(token string) (string, error)
+	Handle(value string) string
+}
+
+type linkLimiterManager interface {
+	Allow(key string) (bool, time.Duration)
+}
+
+type subscriptionListClientManager interface {
+	ListSubscriptions(ctx context.Context, channelType, channelValue string) ([]subscriptionclient.Subscription, error)
+}
+
+// ManageHandler serves the magic-link manage portal: POST /api/v1/manage/link
+// mails a signed link to an email address with subscriptions,
+// GET /api/v1/manage/subscriptions lists them for the bearer of that link, and
+// PATCH and DELETE /api/v1/manage/subscriptions/{id} and POST .../{id}/pause
+// and /resume act on one of them while the link is valid. All answer 404
+// while no signing secret is configured.
+type ManageHandler struct {
+	publisher commandPublisherManager
+	client    subscriptionListClientManager
+	signer    manageTokenManager
+	limiter   linkLimiterManager
+	pageURL   string
+}
+
+// NewManageHandler builds the portal handler; signer nil disables the portal
+// and limiter nil lets an address request any number of links.
+func NewManageHandler(
+	publisher commandPublisherManager,
+	client subscriptionListClientManager,
+	signer manageTokenManager,
+	limiter linkLimiterManager,
+	pageURL string,
+) *ManageHandler {
+	return &ManageHandler{
+		publisher: publisher,
+		client:    client,
+		signer:    signer,
+		limiter:   limiter,
+		pageURL:   pageURL,
+	}
+}
+
+// RequestLink always answers 202 for a valid address below its rate limit, so
+// it does not reveal whether the address has subscriptions.
+func (h *ManageHandler) RequestLink(w http.ResponseWriter, r *http.Request) {
+	if h.signer == nil {
+		APINotFound(w, r)
+		return
+	}
+	var req ManageLinkRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIRequestBody)).Decode(&req); err != nil {
+		WriteAPIError(w, http.StatusBadRequest, ErrCodeInvalidBody, "request body must be a JSON object with an email")
+		return
+	}
+	if err := validateRecipient(emailChannel, req.Email); err != nil {
+		WriteAPIError(w, http.StatusBadRequest, ErrCodeValidationFailed, err.Error())
+		return
+	}
+	if h.limiter != nil {
+		if ok, retryAfter := h.limiter.Allow(strings.ToLower(req.Email)); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			WriteAPIError(w, http.StatusTooManyRequests, ErrCodeRateLimited,
+				"too many manage links were requested for this address, try again later")
+			return
+		}
+	}
+
+	token, expiresAt, err := h.signer.Issue(req.Email)
+	if err != nil {
+		log.Printf("[ManageHandler] failed to issue manage token: %v", err)
+		WriteAPIError(w, http.StatusInternalServerError, ErrCodeInternal, "failed to issue manage link")
+		return
+	}
+	cmd := SubscriptionCommand{
+		CommandID:     uuid.NewString(),
+		RequestID:     newRequestID(),
+		Command:       "manage_link",
+		ChannelType:   emailChannel,
+		ChannelValue:  req.Email,
+		Link:          h.pageURL + "#token=" + token,
+		LinkExpiresAt: expiresAt.Unix(),
+	}
+	publishAPICommand(w, r, h.publisher, req.Email, cmd, "If the address has subscriptions, a manage link is on its way")
+}
+
+func (h *ManageHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	email, ok := h.verifyLink(w, r)
+	if !ok {
+		return
+	}
+	subs, ok := h.listSubscriptions(w, r, email)
+	if !ok {
+		return
+	}
+
+	resp := ManagedSubscriptionsResponse{
+		Email:         email,
+		Subscriptions: make([]ManagedSubscription, 0, len(subs)),
+	}
+	for _, s := range subs {
+		resp.Subscriptions = append(resp.Subscriptions, ManagedSubscription{
+			ID:           h.signer.Handle(s.Token),
+			City:         s.City,
+			Frequency:    frequency.Format(s.FrequencyMinutes, s.DeliveryTime, s.DeliveryDays),
+			DeliveryTime: s.DeliveryTime,
+			Timezone:     s.Timezone,
+			Confirmed:    s.Confirmed,
+			Paused:       s.PausedAt != nil,
+			CreatedAt:    s.CreatedAt,
+		})
+	}
+	w.Header().Set("Content-Type", jsonContentType)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ManageHandler] failed to encode response: %v", err)
+	}
+}
+
+func (h *ManageHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req ScheduleRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIRequestBody)).Decode(&req); err != nil {
+		WriteAPIError(w, http.StatusBadRequest, ErrCodeInvalidBody, "request body must be a JSON schedule object")
+		return
+	}
+	h.manageSubscription(w, r, func(token string) (SubscriptionCommand, error) {
+		return newUpdateCommand(token, req)
+	}, "Update event published")
+}
+
+func (h *ManageHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	h.manageSubscription(w, r, tokenCommandFor("unsubscribe"), "Unsubscribe event published")
+}
+
+func (h *ManageHandler) PauseSubscription(w http.ResponseWriter, r *http.Request) {
+	h.manageSubscription(w, r, tokenCommandFor("pause"), "Pause event published")
+}
+
+func (h *ManageHandler) ResumeSubscription(w http.ResponseWriter, r *http.Request) {
+	h.manageSubscription(w, r, tokenCommandFor("resume"), "Resume event published")
+}
+
+func tokenCommandFor(command string) func(token string) (SubscriptionCommand, error) {
+	return func(token string) (SubscriptionCommand, error) {
+		return newTokenCommand(command, token), nil
+	}
+}
+
+// manageSubscription resolves the {id} of a subscription of the link holder
+// to its management token and publishes the command build returns for it.
+func (h *ManageHandler) manageSubscription(
+	w http.ResponseWriter,
+	r *http.Request,
+	build func(token string) (SubscriptionCommand, error),
+	successMsg string,
+) {
+	email, ok := h.verifyLink(w, r)
+	if !ok {
+		return
+	}
+	subs, ok := h.listSubscriptions(w, r, email)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	var token string
+	for _, s := range subs {
+		if subtle.ConstantTimeCompare([]byte(h.signer.Handle(s.Token)), []byte(id)) == 1 {
+			token = s.Token
+			break
+		}
+	}
+	if token == "" {
+		WriteAPIError(w, http.StatusNotFound, ErrCodeNotFound, "no such subscription for this manage link")
+		return
+	}
+
+	cmd, err := build(token)
+	if err != nil {
+		WriteAPIError(w, http.StatusBadRequest, ErrCodeValidationFailed, err.Error())
+		return
+	}
+	publishAPICommand(w, r, h.publisher, token, cmd, successMsg)
+}
+
+// verifyLink returns the email address of the manage link in the
+// Authorization header, or answers the request and returns false.
+func (h *ManageHandler) verifyLink(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if h.signer == nil {
+		APINotFound(w, r)
+		return "", false
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		WriteAPIError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "a manage link token is required")
+		return "", false
+	}
+	email, err := h.signer.Verify(token)
+	if errors.Is(err, magiclink.ErrExpiredToken) {
+		WriteAPIError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "the manage link has expired, request a new one")
+		return "", false
+	}
+	if err != nil {
+		WriteAPIError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "invalid manage link")
+		return "", false
+	}
+	return email, true
+}
+
+func (h *ManageHandler) listSubscriptions(
+	w http.ResponseWriter,
+	r *http.Request,
+	email string,
+) ([]subscriptionclient.Subscription, bool) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaultRequestTimeout)
+	defer cancel()
+	subs, err := h.client.ListSubscriptions(ctx, emailChannel, email)
+	if err != nil {
+		log.Printf("[ManageHandler] failed to list subscriptions: %v", err)
+		WriteAPIError(w, http.StatusBadGateway, ErrCodeUpstreamFailed, "failed to list subscriptions")
+		return nil, false
+	}
+	return subs, true
+}
@@ -0,0 +1,184 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"api-gateway/internal/handlers"
+	"api-gateway/internal/magiclink"
+	"api-gateway/internal/ratelimit"
+	"api-gateway/internal/subscriptionclient"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type stubListClient struct {
+	subs []subscriptionclient.Subscription
+}
+
+func (c stubListClient) ListSubscriptions(_ context.Context, _, _ string) ([]subscriptionclient.Subscription, error) {
+	return c.subs, nil
+}
+
+const managedToken = "0f8d2b7c-3c1e-4a57-9b7e-2f4d6a8c1e90"
+
+func newManageRouter(t *testing.T, publisher *recordingPublisher, limiter *ratelimit.Limiter) (http.Handler, *magiclink.Signer) {
+	t.Helper()
+	signer := magiclink.NewSigner("test-secret", 15*time.Minute)
+	client := stubListClient{subs: []subscriptionclient.Subscription{
+		{Token: managedToken, ChannelType: "email", City: "Kyiv", FrequencyMinutes: 60, Timezone: "UTC", Confirmed: true},
+	}}
+	h := handlers.NewManageHandler(publisher, client, signer, limiter, "https://example.com/manage.html")
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/manage/link", h.RequestLink)
+	r.Get("/api/v1/manage/subscriptions", h.ListSubscriptions)
+	r.Post("/api/v1/manage/subscriptions/{id}/pause", h.PauseSubscription)
+	return r, signer
+}
+
+func manageRequest(t *testing.T, router http.Handler, method, path, bearer, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func issueLink(t *testing.T, signer *magiclink.Signer) string {
+	t.Helper()
+	token, _, err := signer.Issue("user@example.com")
+	if err != nil {
+		t.Fatalf("issue manage token: %v", err)
+	}
+	return token
+}
+
+func TestRequestLink_RateLimitedPerAddress(t *testing.T) {
+	publisher := &recordingPublisher{}
+	router, _ := newManageRouter(t, publisher, ratelimit.NewLimiter(2, time.Hour))
+
+	for i := 0; i < 2; i++ {
+		rec := manageRequest(t, router, http.MethodPost, "/api/v1/manage/link", "", `{"email":"user@example.com"}`)
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("request %d: code = %d, want 202 (body %q)", i+1, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := manageRequest(t, router, http.MethodPost, "/api/v1/manage/link", "", `{"email":"User@Example.com"}`)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("code = %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "3600" {
+		t.Errorf("Retry-After = %q, want 3600", got)
+	}
+	if apiErr := decodeAPIError(t, rec); apiErr.Code != handlers.ErrCodeRateLimited {
+		t.Errorf("error code = %q, want %q", apiErr.Code, handlers.ErrCodeRateLimited)
+	}
+	if len(publisher.published) != 2 {
+		t.Errorf("published %d links, want 2", len(publisher.published))
+	}
+
+	rec = manageRequest(t, router, http.MethodPost, "/api/v1/manage/link", "", `{"email":"other@example.com"}`)
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("other address: code = %d, want 202", rec.Code)
+	}
+}
+
+func TestListSubscriptions_HidesManagementTokens(t *testing.T) {
+	router, signer := newManageRouter(t, &recordingPublisher{}, nil)
+
+	rec := manageRequest(t, router, http.MethodGet, "/api/v1/manage/subscriptions", issueLink(t, signer), "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("code = %d, want 200 (body %q)", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), managedToken) {
+		t.Errorf("response exposes the management token: %s", rec.Body.String())
+	}
+	var resp handlers.ManagedSubscriptionsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Subscriptions) != 1 || resp.Subscriptions[0].ID != signer.Handle(managedToken) {
+		t.Errorf("subscriptions = %+v, want one with the handle of the token", resp.Subscriptions)
+	}
+}
+
+func TestPauseSubscription_ByID(t *testing.T) {
+	tests := []struct {
+		name          string
+		bearer        func(signer *magiclink.Signer) string
+		id            func(signer *magiclink.Signer) string
+		wantCode      int
+		wantPublished bool
+	}{
+		{
+			name:          "listed subscription",
+			bearer:        func(s *magiclink.Signer) string { return issueLink(t, s) },
+			id:            func(s *magiclink.Signer) string { return s.Handle(managedToken) },
+			wantCode:      http.StatusAccepted,
+			wantPublished: true,
+		},
+		{
+			name:     "unknown id",
+			bearer:   func(s *magiclink.Signer) string { return issueLink(t, s) },
+			id:       func(s *magiclink.Signer) string { return s.Handle("another-token") },
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "management token as id",
+			bearer:   func(s *magiclink.Signer) string { return issueLink(t, s) },
+			id:       func(*magiclink.Signer) string { return managedToken },
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "no manage link",
+			bearer:   func(*magiclink.Signer) string { return "" },
+			id:       func(s *magiclink.Signer) string { return s.Handle(managedToken) },
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "invalid manage link",
+			bearer:   func(*magiclink.Signer) string { return "forged.token" },
+			id:       func(s *magiclink.Signer) string { return s.Handle(managedToken) },
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &recordingPublisher{}
+			router, signer := newManageRouter(t, publisher, nil)
+
+			rec := manageRequest(t, router, http.MethodPost,
+				"/api/v1/manage/subscriptions/"+tt.id(signer)+"/pause", tt.bearer(signer), "")
+			if rec.Code != tt.wantCode {
+				t.Fatalf("code = %d, want %d (body %q)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if !tt.wantPublished {
+				if len(publisher.published) != 0 {
+					t.Errorf("published %d commands, want none", len(publisher.published))
+				}
+				return
+			}
+			if len(publisher.published) != 1 {
+				t.Fatalf("published %d commands, want 1", len(publisher.published))
+			}
+			var cmd handlers.SubscriptionCommand
+			if err := json.Unmarshal(publisher.published[0], &cmd); err != nil {
+				t.Fatalf("decode command: %v", err)
+			}
+			if cmd.Command != "pause" || cmd.Token != managedToken {
+				t.Errorf("command = %q for token %q, want pause for %q", cmd.Command, cmd.Token, managedToken)
+			}
+		})
+	}
+}
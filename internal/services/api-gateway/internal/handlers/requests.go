@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"api-gateway/internal/subscriptionclient"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	requestStatusPending = "pending"
+	// requestPendingWindow is how long after issuing a request ID it is
+	// reported as pending before subscription-service has seen the command.
+	requestPendingWindow = 15 * time.Minute
+)
+
+type requestStatusClientManager interface {
+	GetRequestStatus(ctx context.Context, requestID string) (*subscriptionclient.RequestStatus, error)
+}
+
+type RequestStatusHandler struct {
+	client requestStatusClientManager
+}
+
+func NewRequestStatusHandler(client requestStatusClientManager) *RequestStatusHandler {
+	return &RequestStatusHandler{client: client}
+}
+
+func (h *RequestStatusHandler) GetRequestStatus(w http.ResponseWriter, r *http.Request) {
+	requestID := chi.URLParam(r, "id")
+	if err := validateRequestID(requestID); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), defaultRequestTimeout)
+	defer cancel()
+	status, err := h.client.GetRequestStatus(ctx, requestID)
+	if errors.Is(err, subscriptionclient.ErrRequestNotFound) {
+		// A recently issued ID belongs to a command that subscription-service
+		// has not consumed yet; anything else was never issued or got lost.
+		if !issuedRecently(requestID, time.Now()) {
+			http.Error(w, "request not found", http.StatusNotFound)
+			return
+		}
+		status = &subscriptionclient.RequestStatus{RequestID: requestID, Status: requestStatusPending}
+	} else if err != nil {
+		log.Printf("[RequestStatusHandler] lookup failed for %s: %v", requestID, err)
+		http.Error(w, "failed to get request status", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		log.Printf("[RequestStatusHandler] failed to encode response: %v", err)
+	}
+}
+
+// newRequestID returns a time-ordered UUIDv7, so that the issue time of a
+// request can be recovered from its ID alone.
+func newRequestID() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// issuedRecently reports whether requestID was issued by newRequestID within
+// requestPendingWindow of now.
+func issuedRecently(requestID string, now time.Time) bool {
+	id, err := uuid.Parse(requestID)
+	if err != nil || id.Version() != 7 {
+		return false
+	}
+	issuedAt := time.Unix(id.Time().UnixTime())
+	return issuedAt.Before(now.Add(time.Minute)) && now.Sub(issuedAt) <= requestPendingWindow
+}
@@ -0,0 +1,83 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"api-gateway/internal/handlers"
+	"api-gateway/internal/subscriptionclient"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type stubStatusClient struct {
+	statuses map[string]*subscriptionclient.RequestStatus
+}
+
+func (c stubStatusClient) GetRequestStatus(_ context.Context, requestID string) (*subscriptionclient.RequestStatus, error) {
+	if status, ok := c.statuses[requestID]; ok {
+		return status, nil
+	}
+	return nil, subscriptionclient.ErrRequestNotFound
+}
+
+// uuidV7At returns a version 7 UUID whose timestamp is t.
+func uuidV7At(t *testing.T, at time.Time) string {
+	t.Helper()
+	id, err := uuid.NewV7()
+	if err != nil {
+		t.Fatalf("new uuid: %v", err)
+	}
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(at.UnixMilli()))
+	copy(id[:6], ms[2:])
+	return id.String()
+}
+
+func TestGetRequestStatus(t *testing.T) {
+	known := uuidV7At(t, time.Now().Add(-time.Hour))
+	client := stubStatusClient{statuses: map[string]*subscriptionclient.RequestStatus{
+		known: {RequestID: known, Status: "succeeded"},
+	}}
+	r := chi.NewRouter()
+	r.Get("/api/requests/{id}", handlers.NewRequestStatusHandler(client).GetRequestStatus)
+
+	tests := []struct {
+		name       string
+		requestID  string
+		wantCode   int
+		wantStatus string
+	}{
+		{name: "known", requestID: known, wantCode: http.StatusOK, wantStatus: "succeeded"},
+		{name: "recently issued", requestID: uuidV7At(t, time.Now()), wantCode: http.StatusOK, wantStatus: "pending"},
+		{name: "issued long ago", requestID: uuidV7At(t, time.Now().Add(-time.Hour)), wantCode: http.StatusNotFound},
+		{name: "never issued", requestID: uuid.NewString(), wantCode: http.StatusNotFound},
+		{name: "malformed", requestID: "not-a-uuid", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/requests/"+tt.requestID, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("code = %d, want %d (body %q)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantStatus == "" {
+				return
+			}
+			var got subscriptionclient.RequestStatus
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"log"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
 const (
@@ -31,29 +32,20 @@ func (h *SubscribeHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	email := r.FormValue("email")
-	city := r.FormValue("city")
-	frequency := r.FormValue("frequency")
-
-	if err := validateSubscriptionParams(email, city, frequency); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	frequencyMinutes, err := frequencyToMinutes(frequency)
+	cmd, err := newSubscribeCommand(SubscriptionRequest{
+		Channel:      r.FormValue("channel"),
+		Email:        r.FormValue("email"),
+		Phone:        r.FormValue("phone"),
+		URL:          r.FormValue("url"),
+		City:         r.FormValue("city"),
+		Frequency:    r.FormValue("frequency"),
+		DeliveryTime: r.FormValue("delivery_time"),
+		Timezone:     r.FormValue("timezone"),
+	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-
-	cmd := SubscriptionCommand{
-		Command:          "subscribe",
-		ChannelType:      "email",
-		ChannelValue:     email,
-		City:             city,
-		Frequency:        frequency,
-		FrequencyMinutes: frequencyMinutes,
-	}
 	payload, err := json.Marshal(cmd)
 	if err != nil {
 		http.Error(w, "failed to marshal command", http.StatusInternalServerError)
@@ -62,13 +54,90 @@ func (h *SubscribeHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
 
 	ctx, cancel := context.WithTimeout(r.Context(), defaultPublishTimeout)
 	defer cancel()
-	if err := h.Publisher.Publish(ctx, email, payload); err != nil {
+	if err := h.Publisher.Publish(ctx, cmd.ChannelValue, payload); err != nil {
 		log.Printf("failed to publish event: %v", err)
 		http.Error(w, "failed to process subscription request", http.StatusInternalServerError)
 		return
 	}
+	writeCommandAccepted(w, cmd.RequestID, "Subscription event published")
+}
+
+// newSubscribeCommand validates req and builds the subscribe command for it.
+func newSubscribeCommand(req SubscriptionRequest) (SubscriptionCommand, error) {
+	channel := req.Channel
+	if channel == "" {
+		channel = emailChannel
+	}
+	recipient := req.Email
+	switch channel {
+	case smsChannel:
+		recipient = req.Phone
+	case webhookChannel:
+		recipient = req.URL
+	}
+
+	if err := validateSubscriptionParams(channel, recipient, req.City); err != nil {
+		return SubscriptionCommand{}, err
+	}
+	spec, err := parseSchedule(req.Frequency, req.DeliveryTime, req.Timezone)
+	if err != nil {
+		return SubscriptionCommand{}, err
+	}
+
+	return SubscriptionCommand{
+		CommandID:        uuid.NewString(),
+		RequestID:        newRequestID(),
+		Command:          "subscribe",
+		ChannelType:      channel,
+		ChannelValue:     recipient,
+		City:             req.City,
+		Frequency:        req.Frequency,
+		FrequencyMinutes: spec.Minutes,
+		DeliveryTime:     spec.DeliveryTime,
+		DeliveryDays:     spec.Days,
+		Timezone:         req.Timezone,
+	}, nil
+}
+
+func newTokenCommand(command, token string) SubscriptionCommand {
+	return SubscriptionCommand{
+		CommandID: uuid.NewString(),
+		RequestID: newRequestID(),
+		Command:   command,
+		Token:     token,
+	}
+}
+
+// newUpdateCommand validates req and builds the command that reschedules the
+// subscription identified by its management token.
+func newUpdateCommand(token string, req ScheduleRequest) (SubscriptionCommand, error) {
+	if err := validateManagementToken(token); err != nil {
+		return SubscriptionCommand{}, err
+	}
+	spec, err := parseSchedule(req.Frequency, req.DeliveryTime, req.Timezone)
+	if err != nil {
+		return SubscriptionCommand{}, err
+	}
+	cmd := newTokenCommand("update", token)
+	cmd.Frequency = req.Frequency
+	cmd.FrequencyMinutes = spec.Minutes
+	cmd.DeliveryTime = spec.DeliveryTime
+	cmd.DeliveryDays = spec.Days
+	cmd.Timezone = req.Timezone
+	return cmd, nil
+}
+
+func writeCommandAccepted(w http.ResponseWriter, requestID, message string) {
+	statusURL := "/api/requests/" + requestID
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", statusURL)
+	w.Header().Set("X-Request-ID", requestID)
 	w.WriteHeader(http.StatusAccepted)
-	if _, err := w.Write([]byte("Subscription event published")); err != nil {
+	if err := json.NewEncoder(w).Encode(CommandAcceptedResponse{
+		RequestID: requestID,
+		StatusURL: statusURL,
+		Message:   message,
+	}); err != nil {
 		log.Printf("failed to write response: %v", err)
 	}
 }
@@ -85,10 +154,7 @@ func (h *SubscribeHandler) handleTokenCommand(
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	cmd := SubscriptionCommand{
-		Command: command,
-		Token:   token,
-	}
+	cmd := newTokenCommand(command, token)
 	payload, err := json.Marshal(cmd)
 	if err != nil {
 		http.Error(w, "failed to marshal command", http.StatusInternalServerError)
@@ -101,10 +167,7 @@ func (h *SubscribeHandler) handleTokenCommand(
 		http.Error(w, "failed to publish "+command+" event: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
-	w.WriteHeader(http.StatusAccepted)
-	if _, err := w.Write([]byte(successMsg)); err != nil {
-		log.Printf("failed to write response: %v", err)
-	}
+	writeCommandAccepted(w, cmd.RequestID, successMsg)
 }
 
 func (h *SubscribeHandler) ConfirmSubscription(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	jsonContentType   = "application/json"
+	maxAPIRequestBody = 1 << 20
+
+	ErrCodeInvalidBody          = "invalid_body"
+	ErrCodeValidationFailed     = "validation_failed"
+	ErrCodeUnsupportedMediaType = "unsupported_media_type"
+	ErrCodeNotAcceptable        = "not_acceptable"
+	ErrCodeNotFound             = "not_found"
+	ErrCodeMethodNotAllowed     = "method_not_allowed"
+	ErrCodePublishFailed        = "publish_failed"
+	ErrCodeUnauthorized         = "unauthorized"
+	ErrCodeRateLimited          = "rate_limited"
+	ErrCodeUpstreamFailed       = "upstream_failed"
+	ErrCodeInternal             = "internal_error"
+)
+
+// SubscriptionAPIHandler serves the JSON subscription API under /api/v1:
+// POST /subscriptions, POST /subscriptions/{token}/confirm,
+// PATCH and DELETE /subscriptions/{token}, and
+// POST /subscriptions/{token}/pause and /resume. Commands are published like their form
+// counterparts and answered with 202 and a request status URL.
+type SubscriptionAPIHandler struct {
+	publisher commandPublisherManager
+}
+
+func NewSubscriptionAPIHandler(publisher commandPublisherManager) *SubscriptionAPIHandler {
+	return &SubscriptionAPIHandler{publisher: publisher}
+}
+
+func (h *SubscriptionAPIHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req SubscriptionRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIRequestBody)).Decode(&req); err != nil {
+		WriteAPIError(w, http.StatusBadRequest, ErrCodeInvalidBody, "request body must be a JSON subscription object")
+		return
+	}
+	cmd, err := newSubscribeCommand(req)
+	if err != nil {
+		WriteAPIError(w, http.StatusBadRequest, ErrCodeValidationFailed, err.Error())
+		return
+	}
+	h.publish(w, r, cmd.ChannelValue, cmd, "Subscription event published")
+}
+
+func (h *SubscriptionAPIHandler) ConfirmSubscription(w http.ResponseWriter, r *http.Request) {
+	h.tokenCommand(w, r, "confirm", validateConfirmSubscriptionParams, "Confirm event published")
+}
+
+func (h *SubscriptionAPIHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	h.tokenCommand(w, r, "unsubscribe", validateUnsubscribeParams, "Unsubscribe event published")
+}
+
+func (h *SubscriptionAPIHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req ScheduleRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIRequestBody)).Decode(&req); err != nil {
+		WriteAPIError(w, http.StatusBadRequest, ErrCodeInvalidBody, "request body must be a JSON schedule object")
+		return
+	}
+	token := chi.URLParam(r, "token")
+	cmd, err := newUpdateCommand(token, req)
+	if err != nil {
+		WriteAPIError(w, http.StatusBadRequest, ErrCodeValidationFailed, err.Error())
+		return
+	}
+	h.publish(w, r, token, cmd, "Update event published")
+}
+
+func (h *SubscriptionAPIHandler) PauseSubscription(w http.ResponseWriter, r *http.Request) {
+	h.tokenCommand(w, r, "pause", validateManagementToken, "Pause event published")
+}
+
+func (h *SubscriptionAPIHandler) ResumeSubscription(w http.ResponseWriter, r *http.Request) {
+	h.tokenCommand(w, r, "resume", validateManagementToken, "Resume event published")
+}
+
+func (h *SubscriptionAPIHandler) tokenCommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	command string,
+	validateFunc func(string) error,
+	successMsg string,
+) {
+	token := chi.URLParam(r, "token")
+	if err := validateFunc(token); err != nil {
+		WriteAPIError(w, http.StatusBadRequest, ErrCodeValidationFailed, err.Error())
+		return
+	}
+	h.publish(w, r, token, newTokenCommand(command, token), successMsg)
+}
+
+func (h *SubscriptionAPIHandler) publish(
+	w http.ResponseWriter,
+	r *http.Request,
+	key string,
+	cmd SubscriptionCommand,
+	successMsg string,
+) {
+	publishAPICommand(w, r, h.publisher, key, cmd, successMsg)
+}
+
+// publishAPICommand publishes cmd and answers with 202, or with a JSON error
+// when publishing fails.
+func publishAPICommand(
+	w http.ResponseWriter,
+	r *http.Request,
+	publisher commandPublisherManager,
+	key string,
+	cmd SubscriptionCommand,
+	successMsg string,
+) {
+	payload, err := json.Marshal(cmd)
+	if err != nil {
+		WriteAPIError(w, http.StatusInternalServerError, ErrCodeInternal, "failed to marshal command")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), defaultPublishTimeout)
+	defer cancel()
+	if err := publisher.Publish(ctx, key, payload); err != nil {
+		log.Printf("[SubscriptionAPIHandler] failed to publish %s command: %v", cmd.Command, err)
+		WriteAPIError(w, http.StatusServiceUnavailable, ErrCodePublishFailed, "failed to process "+cmd.Command+" request")
+		return
+	}
+	writeCommandAccepted(w, cmd.RequestID, successMsg)
+}
+
+// NegotiateJSON rejects requests that do not accept a JSON response. Request
+// bodies are checked against the media types of the OpenAPI document by
+// openapi.Validator.
+func NegotiateJSON(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !acceptsJSON(r.Header.Get("Accept")) {
+			WriteAPIError(w, http.StatusNotAcceptable, ErrCodeNotAcceptable, "responses are only available as "+jsonContentType)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func acceptsJSON(accept string) bool {
+	if accept == "" {
+		return true
+	}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || params["q"] == "0" {
+			continue
+		}
+		switch mediaType {
+		case jsonContentType, "application/*", "*/*":
+			return true
+		}
+	}
+	return false
+}
+
+// APINotFound and APIMethodNotAllowed answer unknown /api/v1 routes with
+// JSON errors.
+func APINotFound(w http.ResponseWriter, r *http.Request) {
+	WriteAPIError(w, http.StatusNotFound, ErrCodeNotFound, "no such endpoint: "+r.Method+" "+r.URL.Path)
+}
+
+func APIMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	WriteAPIError(w, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed, "method "+r.Method+" is not allowed here")
+}
+
+// WriteAPIError writes a structured JSON error with a machine-readable code.
+func WriteAPIError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", jsonContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(ErrorResponse{
+		Error: APIError{Code: code, Message: message},
+	}); err != nil {
+		log.Printf("failed to write error response: %v", err)
+	}
+}
@@ -0,0 +1,231 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api-gateway/internal/handlers"
+)
+
+func postSubscription(t *testing.T, publisher *recordingPublisher, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/subscriptions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handlers.NewSubscriptionAPIHandler(publisher).CreateSubscription(rec, req)
+	return rec
+}
+
+func TestCreateSubscription_Webhook(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		wantCode int
+	}{
+		{name: "host name", url: "https://hooks.example.com/weather", wantCode: http.StatusAccepted},
+		{name: "IPv4 literal", url: "http://169.254.169.254/latest/meta-data", wantCode: http.StatusBadRequest},
+		{name: "IPv6 literal", url: "http://[::1]:8080/hook", wantCode: http.StatusBadRequest},
+		{name: "not http", url: "ftp://hooks.example.com/weather", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &recordingPublisher{}
+			rec := postSubscription(t, publisher,
+				`{"channel":"webhook","url":"`+tt.url+`","city":"Kyiv","frequency":"daily"}`)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("code = %d, want %d (body %q)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if published := len(publisher.published) == 1; published != (tt.wantCode == http.StatusAccepted) {
+				t.Errorf("published %d commands", len(publisher.published))
+			}
+		})
+	}
+}
+
+func decodeAPIError(t *testing.T, rec *httptest.ResponseRecorder) handlers.APIError {
+	t.Helper()
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var body handlers.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode error body: %v", err)
+	}
+	return body.Error
+}
+
+func TestCreateSubscription_BuildsSubscribeCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want handlers.SubscriptionCommand
+	}{
+		{
+			name: "email is the default channel",
+			body: `{"email":"a@b.co","city":"Kyiv","frequency":"every 6h"}`,
+			want: handlers.SubscriptionCommand{
+				ChannelType: "email", ChannelValue: "a@b.co", City: "Kyiv",
+				Frequency: "every 6h", FrequencyMinutes: 360,
+			},
+		},
+		{
+			name: "sms with a weekday schedule",
+			body: `{"channel":"sms","phone":"+380501234567","city":"Lviv","frequency":"07:30 Mon-Fri","timezone":"Europe/Kyiv"}`,
+			want: handlers.SubscriptionCommand{
+				ChannelType: "sms", ChannelValue: "+380501234567", City: "Lviv", Frequency: "07:30 Mon-Fri",
+				FrequencyMinutes: 1440, DeliveryTime: "07:30", DeliveryDays: "Mon-Fri", Timezone: "Europe/Kyiv",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &recordingPublisher{}
+			rec := postSubscription(t, publisher, tt.body)
+			if rec.Code != http.StatusAccepted {
+				t.Fatalf("code = %d, want 202 (body %q)", rec.Code, rec.Body.String())
+			}
+			if len(publisher.published) != 1 {
+				t.Fatalf("published %d commands, want 1", len(publisher.published))
+			}
+
+			var got handlers.SubscriptionCommand
+			if err := json.Unmarshal(publisher.published[0], &got); err != nil {
+				t.Fatalf("decode command: %v", err)
+			}
+			if got.Command != "subscribe" || got.CommandID == "" || got.RequestID == "" {
+				t.Errorf("command = %+v, want a subscribe command with command and request IDs", got)
+			}
+			got.Command, got.CommandID, got.RequestID = "", "", ""
+			if got != tt.want {
+				t.Errorf("command = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateSubscription_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode string
+	}{
+		{name: "malformed JSON", body: `{"email":`, wantCode: handlers.ErrCodeInvalidBody},
+		{name: "missing city", body: `{"email":"a@b.co","frequency":"daily"}`, wantCode: handlers.ErrCodeValidationFailed},
+		{name: "unknown frequency", body: `{"email":"a@b.co","city":"Kyiv","frequency":"weekly"}`, wantCode: handlers.ErrCodeValidationFailed},
+		{name: "unknown timezone", body: `{"email":"a@b.co","city":"Kyiv","frequency":"daily","timezone":"Mars/Base"}`, wantCode: handlers.ErrCodeValidationFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &recordingPublisher{}
+			rec := postSubscription(t, publisher, tt.body)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("code = %d, want 400", rec.Code)
+			}
+			if apiErr := decodeAPIError(t, rec); apiErr.Code != tt.wantCode || apiErr.Message == "" {
+				t.Errorf("error = %+v, want code %q", apiErr, tt.wantCode)
+			}
+			if len(publisher.published) != 0 {
+				t.Errorf("published %d commands for an invalid request", len(publisher.published))
+			}
+		})
+	}
+}
+
+func TestNegotiateJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		accept   string
+		wantCode int
+	}{
+		{name: "no Accept header", wantCode: http.StatusNoContent},
+		{name: "JSON", accept: "application/json", wantCode: http.StatusNoContent},
+		{name: "wildcard", accept: "text/html, */*;q=0.1", wantCode: http.StatusNoContent},
+		{name: "HTML only", accept: "text/html", wantCode: http.StatusNotAcceptable},
+		{name: "JSON refused", accept: "application/json;q=0", wantCode: http.StatusNotAcceptable},
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/manage/subscriptions", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			handlers.NegotiateJSON(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("code = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if tt.wantCode == http.StatusNotAcceptable {
+				if apiErr := decodeAPIError(t, rec); apiErr.Code != handlers.ErrCodeNotAcceptable {
+					t.Errorf("error code = %q, want %q", apiErr.Code, handlers.ErrCodeNotAcceptable)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteAPIError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	handlers.WriteAPIError(rec, http.StatusServiceUnavailable, handlers.ErrCodePublishFailed, "failed to process subscribe request")
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("code = %d, want 503", rec.Code)
+	}
+	want := handlers.APIError{Code: handlers.ErrCodePublishFailed, Message: "failed to process subscribe request"}
+	if got := decodeAPIError(t, rec); got != want {
+		t.Errorf("error = %+v, want %+v", got, want)
+	}
+}
+
+func TestCreateSubscription_SMSPhoneFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		phone    string
+		wantCode int
+	}{
+		{name: "E.164", phone: "+380501234567", wantCode: http.StatusAccepted},
+		{name: "shortest number", phone: "+12", wantCode: http.StatusAccepted},
+		{name: "fifteen digits", phone: "+123456789012345", wantCode: http.StatusAccepted},
+		{name: "missing plus", phone: "380501234567", wantCode: http.StatusBadRequest},
+		{name: "leading zero", phone: "+0501234567", wantCode: http.StatusBadRequest},
+		{name: "sixteen digits", phone: "+1234567890123456", wantCode: http.StatusBadRequest},
+		{name: "spaces", phone: "+380 50 123 4567", wantCode: http.StatusBadRequest},
+		{name: "dashes", phone: "+380-50-123-4567", wantCode: http.StatusBadRequest},
+		{name: "letters", phone: "+38050CALLME", wantCode: http.StatusBadRequest},
+		{name: "empty", phone: "", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &recordingPublisher{}
+			rec := postSubscription(t, publisher,
+				`{"channel":"sms","phone":"`+tt.phone+`","city":"Kyiv","frequency":"daily"}`)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("code = %d, want %d (body %q)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusAccepted {
+				if apiErr := decodeAPIError(t, rec); apiErr.Code != handlers.ErrCodeValidationFailed {
+					t.Errorf("error code = %q, want %q", apiErr.Code, handlers.ErrCodeValidationFailed)
+				}
+				return
+			}
+			var cmd handlers.SubscriptionCommand
+			if len(publisher.published) != 1 {
+				t.Fatalf("published %d commands, want 1", len(publisher.published))
+			}
+			if err := json.Unmarshal(publisher.published[0], &cmd); err != nil {
+				t.Fatalf("decode command: %v", err)
+			}
+			if cmd.ChannelType != "sms" || cmd.ChannelValue != tt.phone {
+				t.Errorf("command channel = %s:%s, want sms:%s", cmd.ChannelType, cmd.ChannelValue, tt.phone)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	telegramSecretHeader  = "X-Telegram-Bot-Api-Secret-Token"
+	maxTelegramUpdateSize = 1 << 20
+
+	telegramUsage = "Commands:\n" +
+		"/subscribe <city> [hourly|daily|every 3h] - get weather updates here\n" +
+		"/confirm <code> - confirm a subscription\n" +
+		"/unsubscribe <code> - stop updates"
+)
+
+type commandPublisherManager interface {
+	Publish(ctx context.Context, key string, value []byte) error
+}
+
+type TelegramUpdate struct {
+	UpdateID int64            `json:"update_id"`
+	Message  *TelegramMessage `json:"message"`
+}
+
+type TelegramMessage struct {
+	Chat TelegramChat `json:"chat"`
+	Text string       `json:"text"`
+}
+
+type TelegramChat struct {
+	ID int64 `json:"id"`
+}
+
+// telegramReply is answered inline in the webhook response, which the Bot API
+// executes as a sendMessage call.
+type telegramReply struct {
+	Method string `json:"method"`
+	ChatID int64  `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+type TelegramHandler struct {
+	publisher commandPublisherManager
+	secret    string
+}
+
+func NewTelegramHandler(publisher commandPublisherManager, secret string) *TelegramHandler {
+	return &TelegramHandler{
+		publisher: publisher,
+		secret:    secret,
+	}
+}
+
+// Webhook turns bot commands into subscription commands for the chat that
+// sent them, using the chat id as the telegram channel value.
+func (h *TelegramHandler) Webhook(w http.ResponseWriter, r *http.Request) {
+	if h.secret == "" {
+		http.Error(w, "telegram webhook is not configured", http.StatusNotFound)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(telegramSecretHeader)), []byte(h.secret)) != 1 {
+		http.Error(w, "invalid secret token", http.StatusUnauthorized)
+		return
+	}
+
+	var update TelegramUpdate
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxTelegramUpdateSize)).Decode(&update); err != nil {
+		http.Error(w, "invalid update", http.StatusBadRequest)
+		return
+	}
+	if update.Message == nil || update.Message.Text == "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	text := h.handleCommand(r.Context(), strconv.FormatInt(chatID, 10), update.Message.Text)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(telegramReply{
+		Method: "sendMessage",
+		ChatID: chatID,
+		Text:   text,
+	}); err != nil {
+		log.Printf("[TelegramHandler] failed to write reply: %v", err)
+	}
+}
+
+func (h *TelegramHandler) handleCommand(ctx context.Context, chatID, text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return telegramUsage
+	}
+	command := strings.SplitN(fields[0], "@", 2)[0]
+	args := fields[1:]
+
+	switch command {
+	case "/subscribe":
+		city, frequency := parseTelegramSubscribeArgs(args)
+		if err := validateSubscriptionParams(telegramChannel, chatID, city); err != nil {
+			return "Cannot subscribe: " + err.Error() + "\n\n" + telegramUsage
+		}
+		spec, err := parseSchedule(frequency, "", "")
+		if err != nil {
+			return "Cannot subscribe: " + err.Error()
+		}
+		return h.publish(ctx, chatID, SubscriptionCommand{
+			CommandID:        uuid.NewString(),
+			RequestID:        newRequestID(),
+			Command:          "subscribe",
+			ChannelType:      telegramChannel,
+			ChannelValue:     chatID,
+			City:             city,
+			Frequency:        frequency,
+			FrequencyMinutes: spec.Minutes,
+		}, "Subscription requested. A confirmation code will arrive in this chat shortly.")
+	case "/confirm", "/unsubscribe":
+		if len(args) != 1 {
+			return fmt.Sprintf("Usage: %s <code>", command)
+		}
+		return h.publish(ctx, args[0], SubscriptionCommand{
+			CommandID: uuid.NewString(),
+			RequestID: newRequestID(),
+			Command:   strings.TrimPrefix(command, "/"),
+			Token:     args[0],
+		}, "Request accepted.")
+	default:
+		return telegramUsage
+	}
+}
+
+func (h *TelegramHandler) publish(ctx context.Context, key string, cmd SubscriptionCommand, reply string) string {
+	payload, err := json.Marshal(cmd)
+	if err != nil {
+		log.Printf("[TelegramHandler] failed to marshal command: %v", err)
+		return "Something went wrong, please try again later."
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultPublishTimeout)
+	defer cancel()
+	if err := h.publisher.Publish(ctx, key, payload); err != nil {
+		log.Printf("[TelegramHandler] failed to publish %s command: %v", cmd.Command, err)
+		return "Something went wrong, please try again later."
+	}
+	return fmt.Sprintf("%s\nRequest ID: %s", reply, cmd.RequestID)
+}
+
+// parseTelegramSubscribeArgs reads "<city words...> [hourly|daily|every Nh]",
+// defaulting to daily.
+func parseTelegramSubscribeArgs(args []string) (string, string) {
+	frequency := "daily"
+	n := len(args)
+	if n > 2 && strings.EqualFold(args[n-2], "every") {
+		frequency = "every " + strings.ToLower(args[n-1])
+		args = args[:n-2]
+	} else if n > 1 {
+		if last := strings.ToLower(args[n-1]); last == "hourly" || last == "daily" {
+			frequency = last
+			args = args[:n-1]
+		}
+	}
+	return strings.Join(args, " "), frequency
+}
@@ -0,0 +1,176 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api-gateway/internal/handlers"
+)
+
+type recordingPublisher struct {
+	published [][]byte
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, _ string, value []byte) error {
+	p.published = append(p.published, value)
+	return nil
+}
+
+func TestTelegramWebhook_WhitespaceOnlyTextRepliesWithUsage(t *testing.T) {
+	publisher := &recordingPublisher{}
+	h := handlers.NewTelegramHandler(publisher, "secret")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/telegram/webhook",
+		strings.NewReader(`{"update_id":1,"message":{"chat":{"id":42},"text":"  \n\t "}}`))
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "secret")
+	rec := httptest.NewRecorder()
+	h.Webhook(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("code = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var reply struct {
+		ChatID int64  `json:"chat_id"`
+		Text   string `json:"text"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&reply); err != nil {
+		t.Fatalf("decode reply: %v", err)
+	}
+	if reply.ChatID != 42 || !strings.HasPrefix(reply.Text, "Commands:") {
+		t.Errorf("reply = %+v, want usage for chat 42", reply)
+	}
+	if len(publisher.published) != 0 {
+		t.Errorf("published %d commands, want none", len(publisher.published))
+	}
+}
+
+type telegramReply struct {
+	ChatID int64  `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+func sendTelegramText(t *testing.T, publisher *recordingPublisher, secret, text string) *httptest.ResponseRecorder {
+	t.Helper()
+	update, err := json.Marshal(map[string]any{
+		"update_id": 1,
+		"message":   map[string]any{"chat": map[string]any{"id": 42}, "text": text},
+	})
+	if err != nil {
+		t.Fatalf("marshal update: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/telegram/webhook", strings.NewReader(string(update)))
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+	rec := httptest.NewRecorder()
+	handlers.NewTelegramHandler(publisher, "secret").Webhook(rec, req)
+	return rec
+}
+
+func decodeTelegramReply(t *testing.T, rec *httptest.ResponseRecorder) telegramReply {
+	t.Helper()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("code = %d, want %d (body %q)", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var reply telegramReply
+	if err := json.NewDecoder(rec.Body).Decode(&reply); err != nil {
+		t.Fatalf("decode reply: %v", err)
+	}
+	return reply
+}
+
+func TestTelegramWebhook_RejectsSecretMismatch(t *testing.T) {
+	for _, secret := range []string{"", "wrong"} {
+		publisher := &recordingPublisher{}
+		rec := sendTelegramText(t, publisher, secret, "/subscribe Kyiv")
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("secret %q: code = %d, want %d", secret, rec.Code, http.StatusUnauthorized)
+		}
+		if len(publisher.published) != 0 {
+			t.Errorf("secret %q: published %d commands, want none", secret, len(publisher.published))
+		}
+	}
+}
+
+func TestTelegramWebhook_Subscribe(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		wantCity      string
+		wantFrequency string
+		wantMinutes   int
+	}{
+		{name: "interval", text: "/subscribe Kyiv every 6h", wantCity: "Kyiv", wantFrequency: "every 6h", wantMinutes: 360},
+		{name: "multi-word city defaults to daily", text: "/subscribe New York", wantCity: "New York", wantFrequency: "daily", wantMinutes: 1440},
+		{name: "hourly", text: "/subscribe Kyiv HOURLY", wantCity: "Kyiv", wantFrequency: "hourly", wantMinutes: 60},
+		{name: "bot name suffix", text: "/subscribe@WeatherBot Lviv daily", wantCity: "Lviv", wantFrequency: "daily", wantMinutes: 1440},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &recordingPublisher{}
+			reply := decodeTelegramReply(t, sendTelegramText(t, publisher, "secret", tt.text))
+
+			if !strings.HasPrefix(reply.Text, "Subscription requested.") {
+				t.Errorf("reply = %q", reply.Text)
+			}
+			if len(publisher.published) != 1 {
+				t.Fatalf("published %d commands, want 1", len(publisher.published))
+			}
+			var cmd handlers.SubscriptionCommand
+			if err := json.Unmarshal(publisher.published[0], &cmd); err != nil {
+				t.Fatalf("decode command: %v", err)
+			}
+			if cmd.Command != "subscribe" || cmd.ChannelType != "telegram" || cmd.ChannelValue != "42" {
+				t.Errorf("command = %+v, want a telegram subscribe for chat 42", cmd)
+			}
+			if cmd.City != tt.wantCity || cmd.Frequency != tt.wantFrequency || cmd.FrequencyMinutes != tt.wantMinutes {
+				t.Errorf("city, frequency = %q, %q (%d min), want %q, %q (%d min)",
+					cmd.City, cmd.Frequency, cmd.FrequencyMinutes, tt.wantCity, tt.wantFrequency, tt.wantMinutes)
+			}
+		})
+	}
+}
+
+func TestTelegramWebhook_TokenCommands(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		wantCommand string
+		wantReply   string
+	}{
+		{name: "confirm", text: "/confirm abc123", wantCommand: "confirm", wantReply: "Request accepted."},
+		{name: "unsubscribe with bot name", text: "/unsubscribe@WeatherBot abc123", wantCommand: "unsubscribe", wantReply: "Request accepted."},
+		{name: "confirm without code", text: "/confirm", wantReply: "Usage: /confirm <code>"},
+		{name: "confirm with two codes", text: "/confirm abc 123", wantReply: "Usage: /confirm <code>"},
+		{name: "unknown command", text: "/start", wantReply: "Commands:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &recordingPublisher{}
+			reply := decodeTelegramReply(t, sendTelegramText(t, publisher, "secret", tt.text))
+
+			if !strings.HasPrefix(reply.Text, tt.wantReply) {
+				t.Errorf("reply = %q, want it to start with %q", reply.Text, tt.wantReply)
+			}
+			if tt.wantCommand == "" {
+				if len(publisher.published) != 0 {
+					t.Errorf("published %d commands, want none", len(publisher.published))
+				}
+				return
+			}
+			if len(publisher.published) != 1 {
+				t.Fatalf("published %d commands, want 1", len(publisher.published))
+			}
+			var cmd handlers.SubscriptionCommand
+			if err := json.Unmarshal(publisher.published[0], &cmd); err != nil {
+				t.Fatalf("decode command: %v", err)
+			}
+			if cmd.Command != tt.wantCommand || cmd.Token != "abc123" {
+				t.Errorf("command = %q for token %q, want %q for abc123", cmd.Command, cmd.Token, tt.wantCommand)
+			}
+		})
+	}
+}
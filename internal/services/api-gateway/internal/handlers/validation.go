@@ -2,26 +2,36 @@ package handlers
 
 import (
 	"fmt"
+	"net/netip"
+	"net/url"
 	"regexp"
+	"strconv"
+	"time"
+
+	"api-gateway/internal/frequency"
+
+	"github.com/google/uuid"
 )
 
 const (
-	hourlyFrequencyMinutes = 60
-	dailyFrequencyMinutes = 1440
 	maxCityNameLength = 100
 	maxEmailLength = 100
+	maxWebhookURLLength = 2048
+	defaultForecastDays = 1
+	maxForecastDays = 5
 	regexEmail = `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
+	regexE164 = `^\+[1-9][0-9]{1,14}$`
+	regexTelegramChatID = `^-?[0-9]{1,20}$`
+
+	emailChannel = "email"
+	smsChannel = "sms"
+	telegramChannel = "telegram"
+	webhookChannel = "webhook"
 )
 
-func validateSubscriptionParams(email, city, frequency string) error {
-	if email == "" {
-		return fmt.Errorf("email is required")
-	}
-	if matched, _ := regexp.MatchString(regexEmail, email); !matched {
-		return fmt.Errorf("invalid email format")
-	}
-	if len(email) > maxEmailLength {
-		return fmt.Errorf("email too long")
+func validateSubscriptionParams(channel, recipient, city string) error {
+	if err := validateRecipient(channel, recipient); err != nil {
+		return err
 	}
 	if len(city) > maxCityNameLength {
 		return fmt.Errorf("city name too long")
@@ -29,21 +39,65 @@ func validateSubscriptionParams(email, city, frequency string) error {
 	if city == "" {
 		return fmt.Errorf("city is required")
 	}
-	if frequency != "hourly" && frequency != "daily" {
-		return fmt.Errorf("invalid frequency: must be 'hourly' or 'daily'")
-	}
 	return nil
 }
 
-func frequencyToMinutes(frequency string) (int, error) {
-	switch frequency {
-	case "hourly":
-		return hourlyFrequencyMinutes, nil
-	case "daily":
-		return dailyFrequencyMinutes, nil
+func validateRecipient(channel, recipient string) error {
+	switch channel {
+	case emailChannel:
+		if recipient == "" {
+			return fmt.Errorf("email is required")
+		}
+		if matched, _ := regexp.MatchString(regexEmail, recipient); !matched {
+			return fmt.Errorf("invalid email format")
+		}
+		if len(recipient) > maxEmailLength {
+			return fmt.Errorf("email too long")
+		}
+	case smsChannel:
+		if recipient == "" {
+			return fmt.Errorf("phone is required")
+		}
+		if matched, _ := regexp.MatchString(regexE164, recipient); !matched {
+			return fmt.Errorf("invalid phone format: expected E.164, e.g. +380501234567")
+		}
+	case telegramChannel:
+		if matched, _ := regexp.MatchString(regexTelegramChatID, recipient); !matched {
+			return fmt.Errorf("invalid telegram chat id")
+		}
+	case webhookChannel:
+		if recipient == "" {
+			return fmt.Errorf("url is required")
+		}
+		if len(recipient) > maxWebhookURLLength {
+			return fmt.Errorf("url too long")
+		}
+		u, err := url.Parse(recipient)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("invalid url: expected an absolute http(s) URL")
+		}
+		if _, err := netip.ParseAddr(u.Hostname()); err == nil {
+			return fmt.Errorf("invalid url: use a host name rather than an IP address")
+		}
 	default:
-		return 0, fmt.Errorf("invalid frequency")
+		return fmt.Errorf("invalid channel: must be 'email', 'sms', 'telegram' or 'webhook'")
 	}
+	return nil
+}
+
+// parseSchedule validates the frequency, delivery time and time zone of a
+// subscribe or update request.
+func parseSchedule(freq, deliveryTime, timezone string) (frequency.Spec, error) {
+	spec, err := frequency.Parse(freq, deliveryTime)
+	if err != nil {
+		return frequency.Spec{}, err
+	}
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return frequency.Spec{}, fmt.Errorf("invalid timezone")
+		}
+	}
+	return spec, nil
 }
 
 func validateConfirmSubscriptionParams(token string) error {
@@ -60,9 +114,45 @@ func validateUnsubscribeParams(token string) error {
 	return nil
 }
 
+// validateManagementToken checks the token that authorises update, pause and
+// resume; it is the token issued with the subscription.
+func validateManagementToken(token string) error {
+	if token == "" {
+		return fmt.Errorf("token is required")
+	}
+	if _, err := uuid.Parse(token); err != nil {
+		return fmt.Errorf("invalid token")
+	}
+	return nil
+}
+
 func validateWeatherParams(city string) error {
 	if city == "" {
 		return fmt.Errorf("city parameter is required")
 	}
 	return nil
 }
+
+func validateForecastParams(city, days string) (int, error) {
+	if city == "" {
+		return 0, fmt.Errorf("city parameter is required")
+	}
+	if days == "" {
+		return defaultForecastDays, nil
+	}
+	n, err := strconv.Atoi(days)
+	if err != nil {
+		return 0, fmt.Errorf("days must be a number")
+	}
+	if n < 1 || n > maxForecastDays {
+		return 0, fmt.Errorf("days must be between 1 and %d", maxForecastDays)
+	}
+	return n, nil
+}
+
+func validateRequestID(requestID string) error {
+	if _, err := uuid.Parse(requestID); err != nil {
+		return fmt.Errorf("invalid request id")
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"api-gateway/internal/readmodel"
 	"api-gateway/proto"
 )
 
@@ -16,14 +17,23 @@ const (
 
 type weatherClientManager interface {
 	GetWeather(ctx context.Context, req *proto.WeatherRequest) (*proto.WeatherResponse, error)
+	GetForecast(ctx context.Context, req *proto.ForecastRequest) (*proto.ForecastResponse, error)
+}
+
+type weatherReadModelManager interface {
+	Get(city string) (readmodel.WeatherSnapshot, bool)
 }
 
 type WeatherHandler struct {
 	weatherClient weatherClientManager
+	readModel     weatherReadModelManager
 }
 
-func NewWeatherHandler(weatherClient weatherClientManager) *WeatherHandler {
-	return &WeatherHandler{weatherClient: weatherClient}
+func NewWeatherHandler(weatherClient weatherClientManager, readModel weatherReadModelManager) *WeatherHandler {
+	return &WeatherHandler{
+		weatherClient: weatherClient,
+		readModel:     readModel,
+	}
 }
 
 func (h *WeatherHandler) WeatherProxyHandler(w http.ResponseWriter, r *http.Request) {
@@ -34,6 +44,16 @@ func (h *WeatherHandler) WeatherProxyHandler(w http.ResponseWriter, r *http.Requ
 		log.Printf("[WeatherProxyHandler] missing city parameter")
 		return
 	}
+	if snapshot, ok := h.readModel.Get(city); ok {
+		writeWeatherResponse(w, &proto.WeatherResponse{
+			City:        snapshot.City,
+			Description: snapshot.Description,
+			Temperature: snapshot.Temperature,
+			Humidity:    snapshot.Humidity,
+		})
+		log.Printf("[WeatherProxyHandler] served city=%s from read model", city)
+		return
+	}
 	ctx, cancel := context.WithTimeout(r.Context(), defaultRequestTimeout)
 	defer cancel()
 	resp, err := h.weatherClient.GetWeather(ctx, &proto.WeatherRequest{City: city})
@@ -42,9 +62,37 @@ func (h *WeatherHandler) WeatherProxyHandler(w http.ResponseWriter, r *http.Requ
 		log.Printf("[WeatherProxyHandler] gRPC error: %v", err)
 		return
 	}
+	writeWeatherResponse(w, resp)
+	log.Printf("[WeatherProxyHandler] success for city=%s", city)
+}
+
+func writeWeatherResponse(w http.ResponseWriter, resp *proto.WeatherResponse) {
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
 		log.Printf("[WeatherProxyHandler] failed to encode response: %v", err)
 	}
-	log.Printf("[WeatherProxyHandler] success for city=%s", city)
+}
+
+func (h *WeatherHandler) ForecastProxyHandler(w http.ResponseWriter, r *http.Request) {
+	city := r.URL.Query().Get("city")
+	log.Printf("[ForecastProxyHandler] incoming request: %s %s, city=%s", r.Method, r.URL.Path, city)
+	days, err := validateForecastParams(city, r.URL.Query().Get("days"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		log.Printf("[ForecastProxyHandler] invalid parameters: %v", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), defaultRequestTimeout)
+	defer cancel()
+	resp, err := h.weatherClient.GetForecast(ctx, &proto.ForecastRequest{City: city, Days: int32(days)})
+	if err != nil {
+		http.Error(w, "failed to get forecast: "+err.Error(), http.StatusBadGateway)
+		log.Printf("[ForecastProxyHandler] gRPC error: %v", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ForecastProxyHandler] failed to encode response: %v", err)
+	}
+	log.Printf("[ForecastProxyHandler] success for city=%s, days=%d", city, days)
 }
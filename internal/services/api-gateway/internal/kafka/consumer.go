@@ -0,0 +1,174 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	retryDelay    = 200 * time.Millisecond
+	maxRetryDelay = 30 * time.Second
+	minBytes      = 1
+	maxBytes      = 10 * 1024 * 1024
+)
+
+type MessageHandler func(ctx context.Context, message []byte) error
+
+type messageReaderManager interface {
+	ReadMessage(ctx context.Context) (kafka.Message, error)
+	Close() error
+}
+
+// Consumer hands every message published to a topic after it starts to a
+// handler. It reads all partitions from their tail without a consumer group,
+// so every replica sees every message and no committed offsets are left
+// behind; messages published while it reconnects are not replayed.
+type Consumer struct {
+	brokers    []string
+	topic      string
+	handler    MessageHandler
+	partitions func(ctx context.Context) ([]int, error)
+	newReader  func(partition int) messageReaderManager
+}
+
+func NewConsumer(brokers []string, topic string, handler MessageHandler) *Consumer {
+	c := &Consumer{
+		brokers: brokers,
+		topic:   topic,
+		handler: handler,
+	}
+	c.partitions = c.lookupPartitions
+	c.newReader = func(partition int) messageReaderManager {
+		return kafka.NewReader(c.readerConfig(partition))
+	}
+	return c
+}
+
+func (c *Consumer) Start(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.consumeWithRetries(ctx)
+	}()
+	return done
+}
+
+func (c *Consumer) consumeWithRetries(ctx context.Context) {
+	delay := retryDelay
+	for {
+		err := c.consume(ctx)
+		if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return
+		}
+		log.Printf("[Consumer] topic %s failed: %v, retrying in %v", c.topic, err, delay)
+		select {
+		case <-time.After(delay):
+			delay *= 2
+			if delay > maxRetryDelay {
+				delay = maxRetryDelay
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// consume reads all partitions until ctx is done or one of them fails, and
+// returns the first error.
+func (c *Consumer) consume(ctx context.Context) error {
+	partitions, err := c.partitions(ctx)
+	if err != nil {
+		return err
+	}
+	if len(partitions) == 0 {
+		return fmt.Errorf("topic %s has no partitions", c.topic)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for _, partition := range partitions {
+		wg.Add(1)
+		go func(partition int) {
+			defer wg.Done()
+			if err := c.consumePartition(ctx, partition); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(partition)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+func (c *Consumer) consumePartition(ctx context.Context, partition int) error {
+	r := c.newReader(partition)
+	defer func() {
+		if err := r.Close(); err != nil {
+			log.Printf("[Consumer] failed to close reader for topic %s, partition %d: %v", c.topic, partition, err)
+		}
+	}()
+
+	for {
+		m, err := r.ReadMessage(ctx)
+		if err != nil {
+			return err
+		}
+		if err := c.handler(ctx, m.Value); err != nil {
+			log.Printf("[Consumer] handler failed for topic %s, partition %d, offset %d: %v",
+				c.topic, partition, m.Offset, err)
+		}
+	}
+}
+
+func (c *Consumer) readerConfig(partition int) kafka.ReaderConfig {
+	return kafka.ReaderConfig{
+		Brokers:     c.brokers,
+		Topic:       c.topic,
+		Partition:   partition,
+		StartOffset: kafka.LastOffset,
+		MinBytes:    minBytes,
+		MaxBytes:    maxBytes,
+	}
+}
+
+func (c *Consumer) lookupPartitions(ctx context.Context) ([]int, error) {
+	var lastErr error
+	for _, broker := range c.brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		found, err := conn.ReadPartitions(c.topic)
+		if closeErr := conn.Close(); closeErr != nil {
+			log.Printf("[Consumer] failed to close connection to %s: %v", broker, closeErr)
+		}
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		partitions := make([]int, 0, len(found))
+		for _, p := range found {
+			partitions = append(partitions, p.ID)
+		}
+		return partitions, nil
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no brokers configured")
+	}
+	return nil, fmt.Errorf("failed to read partitions of topic %s: %w", c.topic, lastErr)
+}
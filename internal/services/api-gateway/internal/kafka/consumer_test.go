@@ -0,0 +1,126 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// sliceReader returns its messages in order, then err, or blocks until the
+// context is done when err is nil.
+type sliceReader struct {
+	messages []kafka.Message
+	err      error
+	closed   bool
+}
+
+func (r *sliceReader) ReadMessage(ctx context.Context) (kafka.Message, error) {
+	if len(r.messages) > 0 {
+		m := r.messages[0]
+		r.messages = r.messages[1:]
+		return m, nil
+	}
+	if r.err != nil {
+		return kafka.Message{}, r.err
+	}
+	<-ctx.Done()
+	return kafka.Message{}, ctx.Err()
+}
+
+func (r *sliceReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+func newTestConsumer(readers map[int]*sliceReader, handler MessageHandler) *Consumer {
+	c := NewConsumer([]string{"localhost:9092"}, "weather.city_updated", handler)
+	c.partitions = func(context.Context) ([]int, error) {
+		partitions := make([]int, 0, len(readers))
+		for p := range readers {
+			partitions = append(partitions, p)
+		}
+		return partitions, nil
+	}
+	c.newReader = func(partition int) messageReaderManager {
+		return readers[partition]
+	}
+	return c
+}
+
+func TestConsumer_ReadsEveryPartitionFromTheTail(t *testing.T) {
+	c := NewConsumer([]string{"localhost:9092"}, "weather.city_updated", nil)
+	cfg := c.readerConfig(2)
+
+	if cfg.GroupID != "" {
+		t.Errorf("GroupID = %q, want none", cfg.GroupID)
+	}
+	if cfg.StartOffset != kafka.LastOffset {
+		t.Errorf("StartOffset = %d, want LastOffset", cfg.StartOffset)
+	}
+	if cfg.Partition != 2 || cfg.Topic != "weather.city_updated" {
+		t.Errorf("reader reads %s/%d, want weather.city_updated/2", cfg.Topic, cfg.Partition)
+	}
+}
+
+func TestConsumer_HandlesMessagesOfAllPartitions(t *testing.T) {
+	readers := map[int]*sliceReader{
+		0: {messages: []kafka.Message{{Value: []byte("a")}, {Value: []byte("b")}}},
+		1: {messages: []kafka.Message{{Value: []byte("c")}}},
+	}
+	var (
+		mu      sync.Mutex
+		handled []string
+	)
+	allHandled := make(chan struct{})
+	c := newTestConsumer(readers, func(_ context.Context, message []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		handled = append(handled, string(message))
+		if len(handled) == 3 {
+			close(allHandled)
+		}
+		// A failing handler must not stop the consumer.
+		return errors.New("handler failed")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := c.Start(ctx)
+	select {
+	case <-allHandled:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for messages")
+	}
+	cancel()
+	<-done
+
+	sort.Strings(handled)
+	if got := len(handled); got != 3 || handled[0] != "a" || handled[1] != "b" || handled[2] != "c" {
+		t.Errorf("handled = %v, want [a b c]", handled)
+	}
+	for p, r := range readers {
+		if !r.closed {
+			t.Errorf("reader of partition %d was not closed", p)
+		}
+	}
+}
+
+func TestConsumer_ConsumeStopsAllPartitionsOnError(t *testing.T) {
+	readErr := errors.New("broker gone")
+	readers := map[int]*sliceReader{
+		0: {err: readErr},
+		1: {},
+	}
+	c := newTestConsumer(readers, func(context.Context, []byte) error { return nil })
+
+	if err := c.consume(context.Background()); !errors.Is(err, readErr) {
+		t.Fatalf("consume error = %v, want %v", err, readErr)
+	}
+	if !readers[1].closed {
+		t.Error("reader of the healthy partition was not closed")
+	}
+}
@@ -0,0 +1,60 @@
+package magiclink_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"api-gateway/internal/magiclink"
+)
+
+func TestSigner_IssueAndVerify(t *testing.T) {
+	now := time.Date(2025, 6, 10, 8, 0, 0, 0, time.UTC)
+	signer := magiclink.NewSigner("secret", 15*time.Minute).WithClock(func() time.Time { return now })
+
+	token, expiresAt, err := signer.Issue("user@example.com")
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if want := now.Add(15 * time.Minute); !expiresAt.Equal(want) {
+		t.Errorf("expiresAt = %v, want %v", expiresAt, want)
+	}
+
+	subject, err := signer.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if subject != "user@example.com" {
+		t.Errorf("subject = %q, want %q", subject, "user@example.com")
+	}
+}
+
+func TestSigner_VerifyRejects(t *testing.T) {
+	now := time.Date(2025, 6, 10, 8, 0, 0, 0, time.UTC)
+	signer := magiclink.NewSigner("secret", 15*time.Minute).WithClock(func() time.Time { return now })
+	token, _, err := signer.Issue("user@example.com")
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	payload, _, _ := strings.Cut(token, ".")
+
+	for _, tc := range []struct {
+		name   string
+		signer *magiclink.Signer
+		token  string
+		want   error
+	}{
+		{"expired", signer.WithClock(func() time.Time { return now.Add(15 * time.Minute) }), token, magiclink.ErrExpiredToken},
+		{"other secret", magiclink.NewSigner("other", 15*time.Minute), token, magiclink.ErrInvalidToken},
+		{"tampered payload", signer, "e30." + strings.SplitN(token, ".", 2)[1], magiclink.ErrInvalidToken},
+		{"missing signature", signer, payload, magiclink.ErrInvalidToken},
+		{"empty", signer, "", magiclink.ErrInvalidToken},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.signer.Verify(tc.token); !errors.Is(err, tc.want) {
+				t.Errorf("Verify() error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"api-gateway/internal/handlers"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+// Validator rejects requests whose parameters or body do not match the
+// OpenAPI document. Requests to paths the document does not describe are
+// passed through for the router to answer.
+type Validator struct {
+	router  routers.Router
+	options *openapi3filter.Options
+}
+
+func NewValidator(doc *openapi3.T) (*Validator, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build openapi router: %w", err)
+	}
+	options := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+	options.WithCustomSchemaErrorFunc(func(err *openapi3.SchemaError) string {
+		return err.Reason
+	})
+	return &Validator{router: router, options: options}, nil
+}
+
+func (v *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := v.router.FindRoute(r)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !acceptsContentType(route.Operation, r) {
+			handlers.WriteAPIError(w, http.StatusUnsupportedMediaType, handlers.ErrCodeUnsupportedMediaType,
+				"unsupported Content-Type: "+r.Header.Get("Content-Type"))
+			return
+		}
+		if err := openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    v.options,
+		}); err != nil {
+			handlers.WriteAPIError(w, http.StatusBadRequest, handlers.ErrCodeValidationFailed, validationMessage(err))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// acceptsContentType reports whether the request body's media type is one the
+// operation declares; a body without a Content-Type is rejected. Requests
+// without a body are left to ValidateRequest.
+func acceptsContentType(op *openapi3.Operation, r *http.Request) bool {
+	if op.RequestBody == nil || op.RequestBody.Value == nil {
+		return true
+	}
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return r.ContentLength == 0
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return op.RequestBody.Value.Content.Get(mediaType) != nil
+}
+
+func validationMessage(err error) string {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return err.Error()
+	}
+	var schemaErr *openapi3.SchemaError
+	switch {
+	case requestErr.Parameter != nil:
+		return fmt.Sprintf("invalid %s parameter %q: %v", requestErr.Parameter.In, requestErr.Parameter.Name, requestErr.Err)
+	case errors.As(requestErr.Err, &schemaErr):
+		if pointer := schemaErr.JSONPointer(); len(pointer) > 0 {
+			return fmt.Sprintf("invalid request body: %s: %s", pointer[0], schemaErr.Reason)
+		}
+		return "invalid request body: " + schemaErr.Reason
+	case requestErr.Err != nil:
+		return "invalid request body: " + requestErr.Err.Error()
+	default:
+		return requestErr.Reason
+	}
+}
@@ -0,0 +1,111 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"api-gateway/internal/handlers"
+	"api-gateway/internal/openapi"
+)
+
+func newValidatedHandler(t *testing.T) http.Handler {
+	t.Helper()
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("load spec: %v", err)
+	}
+	validator, err := openapi.NewValidator(spec)
+	if err != nil {
+		t.Fatalf("new validator: %v", err)
+	}
+	return validator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+}
+
+func TestValidator(t *testing.T) {
+	h := newValidatedHandler(t)
+	for _, tc := range []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		wantStatus  int
+		wantCode    string
+	}{
+		{
+			name: "valid subscription", method: http.MethodPost, target: "/api/v1/subscriptions",
+			contentType: "application/json", body: `{"email":"a@b.co","city":"Kyiv","frequency":"daily"}`,
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name: "cron-style frequency", method: http.MethodPost, target: "/api/v1/subscriptions",
+			contentType: "application/json", body: `{"email":"a@b.co","city":"Kyiv","frequency":"07:30 Mon-Fri"}`,
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name: "custom interval", method: http.MethodPost, target: "/api/v1/subscriptions",
+			contentType: "application/json", body: `{"email":"a@b.co","city":"Kyiv","frequency":"every 6h"}`,
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name: "unknown frequency", method: http.MethodPost, target: "/api/v1/subscriptions",
+			contentType: "application/json", body: `{"email":"a@b.co","city":"Kyiv","frequency":"weekly"}`,
+			wantStatus: http.StatusBadRequest, wantCode: handlers.ErrCodeValidationFailed,
+		},
+		{
+			name: "missing city", method: http.MethodPost, target: "/api/v1/subscriptions",
+			contentType: "application/json", body: `{"email":"a@b.co","frequency":"daily"}`,
+			wantStatus: http.StatusBadRequest, wantCode: handlers.ErrCodeValidationFailed,
+		},
+		{
+			name: "form body on the JSON API", method: http.MethodPost, target: "/api/v1/subscriptions",
+			contentType: "application/x-www-form-urlencoded", body: "city=Kyiv&frequency=daily",
+			wantStatus: http.StatusUnsupportedMediaType, wantCode: handlers.ErrCodeUnsupportedMediaType,
+		},
+		{
+			name: "JSON API body without a Content-Type", method: http.MethodPost, target: "/api/v1/subscriptions",
+			body:       `{"email":"a@b.co","city":"Kyiv","frequency":"daily"}`,
+			wantStatus: http.StatusUnsupportedMediaType, wantCode: handlers.ErrCodeUnsupportedMediaType,
+		},
+		{
+			name: "forecast days out of range", method: http.MethodGet, target: "/api/forecast?city=Kyiv&days=9",
+			wantStatus: http.StatusBadRequest, wantCode: handlers.ErrCodeValidationFailed,
+		},
+		{
+			name: "weather without city", method: http.MethodGet, target: "/api/weather",
+			wantStatus: http.StatusBadRequest, wantCode: handlers.ErrCodeValidationFailed,
+		},
+		{
+			name: "path outside the spec", method: http.MethodGet, target: "/api/nope",
+			wantStatus: http.StatusAccepted,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, bytes.NewBufferString(tc.body))
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d, body = %s", rec.Code, tc.wantStatus, rec.Body)
+			}
+			if tc.wantCode == "" {
+				return
+			}
+			var body handlers.ErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("decode error body: %v", err)
+			}
+			if body.Error.Code != tc.wantCode || body.Error.Message == "" {
+				t.Errorf("error = %+v, want code %q", body.Error, tc.wantCode)
+			}
+		})
+	}
+}
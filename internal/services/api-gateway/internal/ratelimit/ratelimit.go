@@ -0,0 +1,76 @@
+// Package ratelimit counts requests per key in fixed windows, in memory.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+type window struct {
+	start time.Time
+	count int
+}
+
+// Limiter allows up to limit requests per key in every window. Counts are
+// kept per process, so each gateway replica enforces its own limit.
+type Limiter struct {
+	limit  int
+	period time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	windows   map[string]*window
+	lastPrune time.Time
+}
+
+func NewLimiter(limit int, period time.Duration) *Limiter {
+	return &Limiter{
+		limit:   limit,
+		period:  period,
+		now:     time.Now,
+		windows: make(map[string]*window),
+	}
+}
+
+// WithClock returns a new Limiter with the limits of l that reads the current
+// time from now.
+func (l *Limiter) WithClock(now func() time.Time) *Limiter {
+	clocked := NewLimiter(l.limit, l.period)
+	clocked.now = now
+	return clocked
+}
+
+// Allow counts a request for key. When the limit is reached it returns false
+// and how long until the window of key resets.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(now)
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.period {
+		w = &window{start: now}
+		l.windows[key] = w
+	}
+	if w.count >= l.limit {
+		return false, w.start.Add(l.period).Sub(now)
+	}
+	w.count++
+	return true, 0
+}
+
+// prune drops expired windows at most once per period, so keys that are
+// never seen again do not accumulate.
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < l.period {
+		return
+	}
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= l.period {
+			delete(l.windows, key)
+		}
+	}
+	l.lastPrune = now
+}
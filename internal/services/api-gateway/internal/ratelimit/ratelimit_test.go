@@ -0,0 +1,36 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"api-gateway/internal/ratelimit"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Date(2025, 6, 10, 8, 0, 0, 0, time.UTC)
+	limiter := ratelimit.NewLimiter(2, time.Hour).WithClock(func() time.Time { return now })
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := limiter.Allow("a@example.com"); !ok {
+			t.Fatalf("request %d was limited", i+1)
+		}
+	}
+
+	now = now.Add(15 * time.Minute)
+	ok, retryAfter := limiter.Allow("a@example.com")
+	if ok {
+		t.Fatal("third request in the window was allowed")
+	}
+	if retryAfter != 45*time.Minute {
+		t.Errorf("retryAfter = %v, want 45m", retryAfter)
+	}
+	if ok, _ := limiter.Allow("b@example.com"); !ok {
+		t.Error("another key was limited")
+	}
+
+	now = now.Add(45 * time.Minute)
+	if ok, _ := limiter.Allow("a@example.com"); !ok {
+		t.Error("request after the window reset was limited")
+	}
+}
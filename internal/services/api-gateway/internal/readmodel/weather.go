@@ -0,0 +1,100 @@
+package readmodel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const weatherUpdatedEventType = "weather.city_updated"
+
+type WeatherSnapshot struct {
+	City        string    `json:"city"`
+	Description string    `json:"description"`
+	Temperature float64   `json:"temperature"`
+	Humidity    float64   `json:"humidity"`
+	Provider    string    `json:"provider"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+type weatherUpdatedEvent struct {
+	EventType string `json:"event_type"`
+	City      string `json:"city"`
+	Metrics   struct {
+		City        string  `json:"city"`
+		Description string  `json:"description"`
+		Temperature float64 `json:"temperature"`
+		Humidity    float64 `json:"humidity"`
+	} `json:"metrics"`
+	Provider  string `json:"provider"`
+	FetchedAt int64  `json:"fetched_at"`
+}
+
+// WeatherStore keeps the latest weather per city as published by weather-service.
+type WeatherStore struct {
+	mu     sync.RWMutex
+	ttl    time.Duration
+	cities map[string]WeatherSnapshot
+}
+
+func NewWeatherStore(ttl time.Duration) *WeatherStore {
+	return &WeatherStore{
+		ttl:    ttl,
+		cities: make(map[string]WeatherSnapshot),
+	}
+}
+
+func (s *WeatherStore) Get(city string) (WeatherSnapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshot, ok := s.cities[normalizeCity(city)]
+	if !ok || time.Since(snapshot.FetchedAt) > s.ttl {
+		return WeatherSnapshot{}, false
+	}
+	return snapshot, true
+}
+
+func (s *WeatherStore) Put(city string, snapshot WeatherSnapshot) {
+	key := normalizeCity(city)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, ok := s.cities[key]; ok && current.FetchedAt.After(snapshot.FetchedAt) {
+		return
+	}
+	s.cities[key] = snapshot
+}
+
+// HandleWeatherUpdated applies a weather.city_updated event; messages of other shapes are ignored.
+func (s *WeatherStore) HandleWeatherUpdated(_ context.Context, message []byte) error {
+	var event weatherUpdatedEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		return fmt.Errorf("failed to parse weather updated event: %w", err)
+	}
+	if event.EventType != weatherUpdatedEventType || event.City == "" {
+		return nil
+	}
+
+	fetchedAt := time.Unix(event.FetchedAt, 0)
+	if event.FetchedAt == 0 {
+		fetchedAt = time.Now()
+	}
+	s.Put(event.City, WeatherSnapshot{
+		City:        event.Metrics.City,
+		Description: event.Metrics.Description,
+		Temperature: event.Metrics.Temperature,
+		Humidity:    event.Metrics.Humidity,
+		Provider:    event.Provider,
+		FetchedAt:   fetchedAt,
+	})
+	return nil
+}
+
+func normalizeCity(city string) string {
+	return strings.ToLower(strings.TrimSpace(city))
+}
@@ -0,0 +1,94 @@
+package readmodel_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"api-gateway/internal/readmodel"
+)
+
+func cityEvent(t *testing.T, eventType, city string, temperature float64, fetchedAt time.Time) []byte {
+	t.Helper()
+	payload, err := json.Marshal(map[string]any{
+		"event_type": eventType,
+		"city":       city,
+		"metrics": map[string]any{
+			"city":        city,
+			"description": "Sunny",
+			"temperature": temperature,
+			"humidity":    40,
+		},
+		"provider":   "weatherapi",
+		"fetched_at": fetchedAt.Unix(),
+	})
+	if err != nil {
+		t.Fatalf("marshal event: %v", err)
+	}
+	return payload
+}
+
+func TestHandleWeatherUpdated_FiltersEventType(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		eventType string
+		wantFound bool
+	}{
+		{name: "city event", eventType: "weather.city_updated", wantFound: true},
+		{name: "subscriber notification", eventType: "weather.updated"},
+		{name: "no event type", eventType: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := readmodel.NewWeatherStore(time.Hour)
+			if err := store.HandleWeatherUpdated(context.Background(), cityEvent(t, tt.eventType, "Kyiv", 21, now)); err != nil {
+				t.Fatalf("HandleWeatherUpdated: %v", err)
+			}
+			snapshot, found := store.Get(" kyiv ")
+			if found != tt.wantFound {
+				t.Fatalf("found = %v, want %v", found, tt.wantFound)
+			}
+			if found && (snapshot.Temperature != 21 || snapshot.Provider != "weatherapi") {
+				t.Errorf("snapshot = %+v", snapshot)
+			}
+		})
+	}
+}
+
+func TestHandleWeatherUpdated_RejectsMalformedMessage(t *testing.T) {
+	store := readmodel.NewWeatherStore(time.Hour)
+	if err := store.HandleWeatherUpdated(context.Background(), []byte("{")); err == nil {
+		t.Fatal("expected an error for a malformed message")
+	}
+}
+
+func TestWeatherStore_GetExpiresAfterTTL(t *testing.T) {
+	store := readmodel.NewWeatherStore(10 * time.Minute)
+	store.Put("Kyiv", readmodel.WeatherSnapshot{City: "Kyiv", FetchedAt: time.Now().Add(-11 * time.Minute)})
+	store.Put("Lviv", readmodel.WeatherSnapshot{City: "Lviv", FetchedAt: time.Now().Add(-9 * time.Minute)})
+
+	if _, found := store.Get("Kyiv"); found {
+		t.Error("snapshot older than the TTL was returned")
+	}
+	if _, found := store.Get("Lviv"); !found {
+		t.Error("snapshot within the TTL was not returned")
+	}
+}
+
+func TestWeatherStore_PutKeepsNewerSnapshot(t *testing.T) {
+	store := readmodel.NewWeatherStore(time.Hour)
+	newer := time.Now().Add(-time.Minute)
+
+	store.Put("Kyiv", readmodel.WeatherSnapshot{Temperature: 21, FetchedAt: newer})
+	store.Put("KYIV", readmodel.WeatherSnapshot{Temperature: 15, FetchedAt: newer.Add(-5 * time.Minute)})
+	if snapshot, _ := store.Get("Kyiv"); snapshot.Temperature != 21 {
+		t.Errorf("temperature = %v after an older update, want 21", snapshot.Temperature)
+	}
+
+	store.Put("Kyiv", readmodel.WeatherSnapshot{Temperature: 23, FetchedAt: newer.Add(time.Minute)})
+	if snapshot, _ := store.Get("Kyiv"); snapshot.Temperature != 23 {
+		t.Errorf("temperature = %v after a newer update, want 23", snapshot.Temperature)
+	}
+}
@@ -4,11 +4,15 @@ import (
 	"log"
 	"net/http"
 
+	"api-gateway/internal/handlers"
+	"api-gateway/internal/openapi"
+
 	"github.com/go-chi/chi/v5"
 )
 
 type weatherHandlerManager interface {
 	WeatherProxyHandler(w http.ResponseWriter, r *http.Request)
+	ForecastProxyHandler(w http.ResponseWriter, r *http.Request)
 }
 
 type subscribeHandlerManager interface {
@@ -17,17 +21,77 @@ type subscribeHandlerManager interface {
 	Unsubscribe(w http.ResponseWriter, r *http.Request)
 }
 
+type subscriptionAPIHandlerManager interface {
+	CreateSubscription(w http.ResponseWriter, r *http.Request)
+	ConfirmSubscription(w http.ResponseWriter, r *http.Request)
+	UpdateSubscription(w http.ResponseWriter, r *http.Request)
+	DeleteSubscription(w http.ResponseWriter, r *http.Request)
+	PauseSubscription(w http.ResponseWriter, r *http.Request)
+	ResumeSubscription(w http.ResponseWriter, r *http.Request)
+}
+
+type manageHandlerManager interface {
+	RequestLink(w http.ResponseWriter, r *http.Request)
+	ListSubscriptions(w http.ResponseWriter, r *http.Request)
+	UpdateSubscription(w http.ResponseWriter, r *http.Request)
+	DeleteSubscription(w http.ResponseWriter, r *http.Request)
+	PauseSubscription(w http.ResponseWriter, r *http.Request)
+	ResumeSubscription(w http.ResponseWriter, r *http.Request)
+}
+
+type requestStatusHandlerManager interface {
+	GetRequestStatus(w http.ResponseWriter, r *http.Request)
+}
+
+type telegramHandlerManager interface {
+	Webhook(w http.ResponseWriter, r *http.Request)
+}
+
+// RegisterRoutes registers the public API. Every route must be described in
+// internal/openapi/openapi.json.
 func RegisterRoutes(
 	r chi.Router,
 	weatherHandler weatherHandlerManager,
 	subscribeHandler subscribeHandlerManager,
+	subscriptionAPIHandler subscriptionAPIHandlerManager,
+	manageHandler manageHandlerManager,
+	requestStatusHandler requestStatusHandlerManager,
+	telegramHandler telegramHandlerManager,
 ) {
 	r.Get("/weather", weatherHandler.WeatherProxyHandler)
+	r.Get("/forecast", weatherHandler.ForecastProxyHandler)
 
 	r.Post("/subscribe", subscribeHandler.Subscribe)
 	r.Get("/confirm/{token}", subscribeHandler.ConfirmSubscription)
 	r.Get("/unsubscribe/{token}", subscribeHandler.Unsubscribe)
 
+	r.Route("/v1", func(r chi.Router) {
+		r.Use(handlers.NegotiateJSON)
+		r.NotFound(handlers.APINotFound)
+		r.MethodNotAllowed(handlers.APIMethodNotAllowed)
+
+		r.Post("/subscriptions", subscriptionAPIHandler.CreateSubscription)
+		r.Post("/subscriptions/{token}/confirm", subscriptionAPIHandler.ConfirmSubscription)
+		r.Patch("/subscriptions/{token}", subscriptionAPIHandler.UpdateSubscription)
+		r.Delete("/subscriptions/{token}", subscriptionAPIHandler.DeleteSubscription)
+		r.Post("/subscriptions/{token}/pause", subscriptionAPIHandler.PauseSubscription)
+		r.Post("/subscriptions/{token}/resume", subscriptionAPIHandler.ResumeSubscription)
+
+		r.Post("/manage/link", manageHandler.RequestLink)
+		r.Get("/manage/subscriptions", manageHandler.ListSubscriptions)
+		r.Patch("/manage/subscriptions/{id}", manageHandler.UpdateSubscription)
+		r.Delete("/manage/subscriptions/{id}", manageHandler.DeleteSubscription)
+		r.Post("/manage/subscriptions/{id}/pause", manageHandler.PauseSubscription)
+		r.Post("/manage/subscriptions/{id}/resume", manageHandler.ResumeSubscription)
+	})
+
+	r.Get("/requests/{id}", requestStatusHandler.GetRequestStatus)
+
+	r.Post("/telegram/webhook", telegramHandler.Webhook)
+
+	r.Get("/openapi.json", openapi.SpecHandler)
+	r.Get("/docs", openapi.DocsHandler)
+
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(200)
 		if _, err := w.Write([]byte("OK")); err != nil {
@@ -0,0 +1,57 @@
+package routes_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"api-gateway/internal/handlers"
+	"api-gateway/internal/openapi"
+	"api-gateway/internal/routes"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type nopPublisher struct{}
+
+func (nopPublisher) Publish(context.Context, string, []byte) error { return nil }
+
+func TestRegisterRoutes_EveryRouteHasSpecEntry(t *testing.T) {
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("load spec: %v", err)
+	}
+
+	r := chi.NewRouter()
+	r.Route("/api", func(r chi.Router) {
+		routes.RegisterRoutes(
+			r,
+			handlers.NewWeatherHandler(nil, nil),
+			handlers.NewSubscribeHandler(nil),
+			handlers.NewSubscriptionAPIHandler(nopPublisher{}),
+			handlers.NewManageHandler(nopPublisher{}, nil, nil, nil, ""),
+			handlers.NewRequestStatusHandler(nil),
+			handlers.NewTelegramHandler(nopPublisher{}, ""),
+		)
+	})
+
+	registered := 0
+	err = chi.Walk(r, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		registered++
+		item := spec.Paths.Find(route)
+		if item == nil {
+			t.Errorf("%s %s is registered but has no path in openapi.json", method, route)
+			return nil
+		}
+		if item.GetOperation(method) == nil {
+			t.Errorf("%s %s is registered but openapi.json does not describe %s", method, route, method)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk routes: %v", err)
+	}
+	if registered == 0 {
+		t.Fatal("no routes registered")
+	}
+}
@@ -0,0 +1,120 @@
+package subscriptionclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var ErrRequestNotFound = errors.New("request not found")
+
+type httpClientManager interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type RequestStatus struct {
+	RequestID string    `json:"request_id"`
+	Command   string    `json:"command"`
+	Status    string    `json:"status"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Client struct {
+	httpClient httpClientManager
+	baseURL    string
+}
+
+func New(httpClient httpClientManager, baseURL string) *Client {
+	return &Client{
+		httpClient: httpClient,
+		baseURL:    baseURL,
+	}
+}
+
+func (c *Client) GetRequestStatus(ctx context.Context, requestID string) (*RequestStatus, error) {
+	statusURL := fmt.Sprintf("%s/requests/%s", c.baseURL, url.PathEscape(requestID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, statusURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrRequestNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("subscription-service returned status code: %d", resp.StatusCode)
+	}
+
+	var status RequestStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &status, nil
+}
+
+type Subscription struct {
+	Token            string     `json:"token"`
+	ChannelType      string     `json:"channel_type"`
+	City             string     `json:"city"`
+	FrequencyMinutes int        `json:"frequency_minutes"`
+	DeliveryTime     string     `json:"delivery_time,omitempty"`
+	DeliveryDays     string     `json:"delivery_days,omitempty"`
+	Timezone         string     `json:"timezone"`
+	Confirmed        bool       `json:"confirmed"`
+	PausedAt         *time.Time `json:"paused_at,omitempty"`
+	NextNotifiedAt   time.Time  `json:"next_notified_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// ListSubscriptions returns every subscription of a recipient.
+func (c *Client) ListSubscriptions(ctx context.Context, channelType, channelValue string) ([]Subscription, error) {
+	query := url.Values{}
+	query.Set("channel_type", channelType)
+	query.Set("channel_value", channelValue)
+	listURL := fmt.Sprintf("%s/subscriptions?%s", c.baseURL, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, listURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("subscription-service returned status code: %d", resp.StatusCode)
+	}
+
+	var list struct {
+		Subscriptions []Subscription `json:"subscriptions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return list.Subscriptions, nil
+}
@@ -43,6 +43,10 @@ func (a *WeatherClient) GetWeather(ctx context.Context, req *proto.WeatherReques
 	return a.client.GetWeather(ctx, req)
 }
 
+func (a *WeatherClient) GetForecast(ctx context.Context, req *proto.ForecastRequest) (*proto.ForecastResponse, error) {
+	return a.client.GetForecast(ctx, req)
+}
+
 func (w *WeatherClient) Close() error {
 	return w.conn.Close()
 }
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"notification-service/internal/infrastructure"
+)
+
+const (
+	defaultBrokers = "kafka:9092"
+	defaultLimit   = 50
+	commandTimeout = 30 * time.Second
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage:
+  dlq-admin -topic <dlq-topic> list [-limit N]
+  dlq-admin -topic <dlq-topic> replay (-all | -partition P -offset O)
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
+func main() {
+	brokers := flag.String("brokers", envOrDefault("KAFKA_BROKERS", defaultBrokers), "comma-separated Kafka brokers")
+	topic := flag.String("topic", "", "dead letter topic to inspect")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *topic == "" || flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	brokerList := strings.Split(*brokers, ",")
+	var err error
+	switch flag.Arg(0) {
+	case "list":
+		err = runList(ctx, brokerList, *topic, flag.Args()[1:])
+	case "replay":
+		err = runReplay(ctx, brokerList, *topic, flag.Args()[1:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatalf("dlq-admin: %v", err)
+	}
+}
+
+func runList(ctx context.Context, brokers []string, topic string, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	limit := fs.Int("limit", defaultLimit, "maximum number of messages to show")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	messages, err := infrastructure.ReadDeadLetters(ctx, brokers[0], topic, *limit)
+	if err != nil {
+		return err
+	}
+	for _, m := range messages {
+		fmt.Printf("partition=%d offset=%d key=%s\n", m.Partition, m.Offset, string(m.Key))
+		for _, h := range m.Headers {
+			fmt.Printf("  %s: %s\n", h.Key, string(h.Value))
+		}
+		fmt.Printf("  payload: %s\n\n", string(m.Value))
+	}
+	fmt.Printf("%d message(s) in %s\n", len(messages), topic)
+	return nil
+}
+
+func runReplay(ctx context.Context, brokers []string, topic string, args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	all := fs.Bool("all", false, "replay every message in the topic")
+	partition := fs.Int("partition", -1, "partition of the message to replay")
+	offset := fs.Int64("offset", -1, "offset of the message to replay")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !*all && (*partition < 0 || *offset < 0) {
+		return fmt.Errorf("either -all or both -partition and -offset are required")
+	}
+
+	messages, err := infrastructure.ReadDeadLetters(ctx, brokers[0], topic, 0)
+	if err != nil {
+		return err
+	}
+
+	replayed := 0
+	for _, m := range messages {
+		if !*all && (m.Partition != *partition || m.Offset != *offset) {
+			continue
+		}
+		if err := infrastructure.ReplayDeadLetter(ctx, brokers, m); err != nil {
+			return err
+		}
+		replayed++
+		fmt.Printf("replayed partition=%d offset=%d to %s\n", m.Partition, m.Offset,
+			infrastructure.HeaderValue(m.Headers, infrastructure.HeaderSourceTopic))
+	}
+	if replayed == 0 {
+		return fmt.Errorf("no matching messages found in %s", topic)
+	}
+	return nil
+}
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
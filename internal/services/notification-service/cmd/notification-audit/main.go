@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/infrastructure"
+)
+
+const (
+	defaultLogPath = "audit/notifications.jsonl"
+	dateLayout     = "2006-01-02"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage:
+  notification-audit -recipient <email|phone|chat|url> [-from YYYY-MM-DD] [-to YYYY-MM-DD]
+
+Dates are UTC days; -to is inclusive. RFC 3339 timestamps are accepted as exact bounds.
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
+func main() {
+	path := flag.String("file", envOrDefault("AUDIT_LOG_PATH", defaultLogPath), "audit log file")
+	recipient := flag.String("recipient", "", "recipient to look up")
+	from := flag.String("from", "", "earliest day or timestamp to include")
+	to := flag.String("to", "", "latest day or timestamp to include")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *recipient == "" {
+		usage()
+		os.Exit(2)
+	}
+
+	query := infrastructure.AuditQuery{RecipientHash: domain.HashRecipient(*recipient)}
+	var err error
+	if query.From, err = parseBound(*from, false); err != nil {
+		log.Fatalf("notification-audit: invalid -from: %v", err)
+	}
+	if query.To, err = parseBound(*to, true); err != nil {
+		log.Fatalf("notification-audit: invalid -to: %v", err)
+	}
+
+	auditLog, err := infrastructure.NewFileAuditLog(*path)
+	if err != nil {
+		log.Fatalf("notification-audit: %v", err)
+	}
+	events, err := auditLog.Query(query)
+	if err != nil {
+		log.Fatalf("notification-audit: %v", err)
+	}
+	for _, e := range events {
+		fmt.Printf("%s %-19s channel=%s template=%s provider=%s code=%d latency=%dms id=%s",
+			time.Unix(e.SentAt, 0).UTC().Format(time.RFC3339), e.EventType, e.ChannelType,
+			e.Template, e.Provider, e.ResponseCode, e.LatencyMs, e.NotificationID)
+		if e.Error != "" {
+			fmt.Printf(" error=%q", e.Error)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("%d notification(s) found\n", len(events))
+}
+
+// parseBound parses a day or RFC 3339 timestamp. An end-of-range day covers
+// the whole day.
+func parseBound(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		return day.AddDate(0, 0, 1), nil
+	}
+	return day, nil
+}
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -1,11 +1,25 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type Config struct {
-	Server   ServerConfig
-	Kafka    KafkaConfig
-	SendGrid SendGridConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Kafka     KafkaConfig
+	Email     EmailConfig
+	SendGrid  SendGridConfig
+	SMTP      SMTPConfig
+	SMS       SMSConfig
+	Telegram  TelegramConfig
+	Webhook   WebhookConfig
+	Templates TemplatesConfig
+	Dedup     DedupConfig
+	Redis     RedisConfig
+	Audit     AuditConfig
+	Admin     AdminConfig
 }
 
 type ServerConfig struct {
@@ -13,12 +27,121 @@ type ServerConfig struct {
 	GracefulShutdownTimeout time.Duration `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT" default:"30s"`
 }
 
+type DatabaseConfig struct {
+	Host     string `envconfig:"DB_HOST" required:"true" default:"localhost"`
+	Port     int    `envconfig:"DB_PORT" default:"5432"`
+	User     string `envconfig:"DB_USER" required:"true" default:"postgres"`
+	Password string `envconfig:"DB_PASSWORD"`
+	Name     string `envconfig:"DB_NAME" required:"true" default:"notifications"`
+}
+
+// DSN returns the lib/pq connection string for the database.
+func (d DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		d.Host, d.Port, d.User, d.Password, d.Name)
+}
+
 type KafkaConfig struct {
-	Brokers []string `envconfig:"KAFKA_BROKERS" required:"true" default:"kafka:9092"`
+	Brokers   []string          `envconfig:"KAFKA_BROKERS" required:"true" default:"kafka:9092"`
+	DLQTopics map[string]string `envconfig:"KAFKA_DLQ_TOPICS"`
+	DLQSuffix string            `envconfig:"KAFKA_DLQ_SUFFIX" default:".dlq"`
+	// LegacyConfirmedEvents treats subscription.confirmed events without
+	// confirmed_at as confirmation-code requests, as published before
+	// subscription.created was introduced.
+	LegacyConfirmedEvents bool `envconfig:"KAFKA_LEGACY_CONFIRMED_EVENTS" default:"true"`
+	// NotificationSentTopic and NotificationFailedTopic receive an audit event
+	// after every send attempt.
+	NotificationSentTopic   string `envconfig:"KAFKA_NOTIFICATION_SENT_TOPIC" default:"notification.sent"`
+	NotificationFailedTopic string `envconfig:"KAFKA_NOTIFICATION_FAILED_TOPIC" default:"notification.failed"`
+	// SuppressionTopic receives recipient.suppressed events for hard bounces
+	// and spam reports.
+	SuppressionTopic string `envconfig:"KAFKA_SUPPRESSION_TOPIC" default:"recipient.suppressed"`
 }
 
-type SendGridConfig struct {
-	APIKey      string `envconfig:"SENDGRID_API_KEY" required:"true"`
+// DeadLetterTopic returns the DLQ topic for a source topic, preferring an
+// explicit KAFKA_DLQ_TOPICS mapping over the KAFKA_DLQ_SUFFIX convention.
+func (k KafkaConfig) DeadLetterTopic(sourceTopic string) string {
+	if topic, ok := k.DLQTopics[sourceTopic]; ok && topic != "" {
+		return topic
+	}
+	return sourceTopic + k.DLQSuffix
+}
+
+type EmailConfig struct {
+	Provider    string `envconfig:"EMAIL_PROVIDER" default:"sendgrid"`
 	SenderEmail string `envconfig:"SENDER_EMAIL" required:"true"`
 	SenderName  string `envconfig:"SENDER_NAME" required:"true"`
 }
+
+type SendGridConfig struct {
+	APIKey string `envconfig:"SENDGRID_API_KEY"`
+	// WebhookPublicKey is the base64 verification key of the signed event
+	// webhook; empty disables POST /webhooks/sendgrid.
+	WebhookPublicKey string `envconfig:"SENDGRID_WEBHOOK_PUBLIC_KEY"`
+}
+
+type SMTPConfig struct {
+	Host        string        `envconfig:"SMTP_HOST"`
+	Port        int           `envconfig:"SMTP_PORT" default:"587"`
+	Username    string        `envconfig:"SMTP_USERNAME"`
+	Password    string        `envconfig:"SMTP_PASSWORD"`
+	Security    string        `envconfig:"SMTP_SECURITY" default:"starttls"`
+	PoolSize    int           `envconfig:"SMTP_POOL_SIZE" default:"2"`
+	DialTimeout time.Duration `envconfig:"SMTP_DIAL_TIMEOUT" default:"10s"`
+	IdleTimeout time.Duration `envconfig:"SMTP_IDLE_TIMEOUT" default:"30s"`
+}
+
+type TemplatesConfig struct {
+	Dir            string         `envconfig:"TEMPLATES_DIR" default:"templates"`
+	ReloadInterval time.Duration  `envconfig:"TEMPLATES_RELOAD_INTERVAL" default:"30s"`
+	Versions       map[string]int `envconfig:"TEMPLATE_VERSIONS"`
+}
+
+type SMSConfig struct {
+	Provider   string `envconfig:"SMS_PROVIDER" default:"none"`
+	APIURL     string `envconfig:"SMS_API_URL" default:"https://api.twilio.com"`
+	AccountSID string `envconfig:"SMS_ACCOUNT_SID"`
+	AuthToken  string `envconfig:"SMS_AUTH_TOKEN"`
+	From       string `envconfig:"SMS_FROM"`
+}
+
+type TelegramConfig struct {
+	BotToken string `envconfig:"TELEGRAM_BOT_TOKEN"`
+	APIURL   string `envconfig:"TELEGRAM_API_URL" default:"https://api.telegram.org"`
+}
+
+type WebhookConfig struct {
+	SigningSecret string `envconfig:"WEBHOOK_SIGNING_SECRET"`
+	// AllowedNetworks lists CIDRs that webhooks may reach even though they
+	// are private, e.g. the network of internal consumers.
+	AllowedNetworks []string      `envconfig:"WEBHOOK_ALLOWED_NETWORKS"`
+	Timeout         time.Duration `envconfig:"WEBHOOK_TIMEOUT" default:"5s"`
+	// DisableAfter counts failed attempts, each retry of an event included.
+	DisableAfter int `envconfig:"WEBHOOK_DISABLE_AFTER" default:"20"`
+}
+
+// DedupConfig controls how long processed event IDs are remembered so that
+// redelivered Kafka messages do not notify recipients twice.
+type DedupConfig struct {
+	Store  string        `envconfig:"DEDUP_STORE" default:"memory"`
+	Window time.Duration `envconfig:"DEDUP_WINDOW" default:"24h"`
+}
+
+type RedisConfig struct {
+	Host     string `envconfig:"REDIS_HOST" default:"localhost"`
+	Port     int    `envconfig:"REDIS_PORT" default:"6379"`
+	Password string `envconfig:"REDIS_PASSWORD"`
+	DB       int    `envconfig:"REDIS_DB" default:"0"`
+}
+
+type AuditConfig struct {
+	// LogPath is the file the audit consumer appends notification events to;
+	// empty disables the consumer.
+	LogPath string `envconfig:"AUDIT_LOG_PATH"`
+}
+
+type AdminConfig struct {
+	// Token guards the delivery history, /suppressions and
+	// /webhook-endpoints admin API as a bearer token; empty disables the API.
+	Token string `envconfig:"ADMIN_API_TOKEN"`
+}
@@ -2,15 +2,38 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"strings"
 )
 
+const (
+	EmailProviderSendGrid = "sendgrid"
+	EmailProviderSMTP     = "smtp"
+
+	SMSProviderNone   = "none"
+	SMSProviderFake   = "fake"
+	SMSProviderTwilio = "twilio"
+
+	DedupStoreNone   = "none"
+	DedupStoreMemory = "memory"
+	DedupStoreRedis  = "redis"
+)
+
 func validate(cfg *Config) error {
 	errors := []string{}
 
 	if cfg.Server.Port == 0 {
 		errors = append(errors, "PORT is required")
 	}
+	if cfg.Database.Host == "" {
+		errors = append(errors, "DB_HOST is required")
+	}
+	if cfg.Database.Port <= 0 || cfg.Database.Port > 65535 {
+		errors = append(errors, "DB_PORT must be within 1-65535")
+	}
+	if cfg.Database.Name == "" {
+		errors = append(errors, "DB_NAME is required")
+	}
 	if len(cfg.Kafka.Brokers) == 0 {
 		errors = append(errors, "KAFKA_BROKERS is required")
 	} else {
@@ -20,18 +43,125 @@ func validate(cfg *Config) error {
 			}
 		}
 	}
-	if cfg.SendGrid.APIKey == "" {
-		errors = append(errors, "SENDGRID_API_KEY is required")
+	if cfg.Kafka.DLQSuffix == "" && len(cfg.Kafka.DLQTopics) == 0 {
+		errors = append(errors, "KAFKA_DLQ_SUFFIX or KAFKA_DLQ_TOPICS is required")
+	}
+	switch cfg.Email.Provider {
+	case EmailProviderSendGrid:
+		if cfg.SendGrid.APIKey == "" {
+			errors = append(errors, "SENDGRID_API_KEY is required")
+		}
+	case EmailProviderSMTP:
+		errors = append(errors, validateSMTP(cfg.SMTP)...)
+	default:
+		errors = append(errors, "EMAIL_PROVIDER must be 'sendgrid' or 'smtp'")
 	}
-	if cfg.SendGrid.SenderEmail == "" {
+	if cfg.Email.SenderEmail == "" {
 		errors = append(errors, "SENDER_EMAIL is required")
 	}
-	if cfg.SendGrid.SenderName == "" {
+	if cfg.Email.SenderName == "" {
 		errors = append(errors, "SENDER_NAME is required")
 	}
 
+	switch cfg.SMS.Provider {
+	case SMSProviderNone, SMSProviderFake:
+	case SMSProviderTwilio:
+		if cfg.SMS.APIURL == "" {
+			errors = append(errors, "SMS_API_URL is required")
+		}
+		if cfg.SMS.AccountSID == "" {
+			errors = append(errors, "SMS_ACCOUNT_SID is required")
+		}
+		if cfg.SMS.AuthToken == "" {
+			errors = append(errors, "SMS_AUTH_TOKEN is required")
+		}
+		if cfg.SMS.From == "" {
+			errors = append(errors, "SMS_FROM is required")
+		}
+	default:
+		errors = append(errors, "SMS_PROVIDER must be 'none', 'fake' or 'twilio'")
+	}
+	if cfg.Telegram.BotToken != "" && cfg.Telegram.APIURL == "" {
+		errors = append(errors, "TELEGRAM_API_URL is required when TELEGRAM_BOT_TOKEN is set")
+	}
+	if cfg.Webhook.SigningSecret != "" {
+		if cfg.Webhook.Timeout <= 0 {
+			errors = append(errors, "WEBHOOK_TIMEOUT must be > 0")
+		}
+		if cfg.Webhook.DisableAfter < 0 {
+			errors = append(errors, "WEBHOOK_DISABLE_AFTER must be >= 0")
+		}
+		for i, network := range cfg.Webhook.AllowedNetworks {
+			if _, err := netip.ParsePrefix(network); err != nil {
+				errors = append(errors, fmt.Sprintf("WEBHOOK_ALLOWED_NETWORKS[%d] must be a CIDR such as 10.0.0.0/8", i))
+			}
+		}
+	}
+	if cfg.Templates.Dir == "" {
+		errors = append(errors, "TEMPLATES_DIR is required")
+	}
+	if cfg.Templates.ReloadInterval < 0 {
+		errors = append(errors, "TEMPLATES_RELOAD_INTERVAL must be >= 0")
+	}
+	for name, version := range cfg.Templates.Versions {
+		if version <= 0 {
+			errors = append(errors, fmt.Sprintf("TEMPLATE_VERSIONS[%s] must be > 0", name))
+		}
+	}
+	if cfg.Kafka.NotificationSentTopic == "" {
+		errors = append(errors, "KAFKA_NOTIFICATION_SENT_TOPIC is required")
+	}
+	if cfg.Kafka.NotificationFailedTopic == "" {
+		errors = append(errors, "KAFKA_NOTIFICATION_FAILED_TOPIC is required")
+	}
+	if cfg.Kafka.SuppressionTopic == "" {
+		errors = append(errors, "KAFKA_SUPPRESSION_TOPIC is required")
+	}
+	switch cfg.Dedup.Store {
+	case DedupStoreNone:
+	case DedupStoreMemory, DedupStoreRedis:
+		if cfg.Dedup.Window <= 0 {
+			errors = append(errors, "DEDUP_WINDOW must be > 0")
+		}
+	default:
+		errors = append(errors, "DEDUP_STORE must be 'none', 'memory' or 'redis'")
+	}
+	if cfg.Dedup.Store == DedupStoreRedis {
+		if cfg.Redis.Host == "" {
+			errors = append(errors, "REDIS_HOST is required")
+		}
+		if cfg.Redis.Port <= 0 || cfg.Redis.Port > 65535 {
+			errors = append(errors, "REDIS_PORT must be within 1-65535")
+		}
+		if cfg.Redis.DB < 0 {
+			errors = append(errors, "REDIS_DB must be >= 0")
+		}
+	}
+
 	if len(errors) > 0 {
 		return fmt.Errorf("config validation errors:\n- %s", strings.Join(errors, "\n- "))
 	}
 	return nil
 }
+
+func validateSMTP(cfg SMTPConfig) []string {
+	errors := []string{}
+	if cfg.Host == "" {
+		errors = append(errors, "SMTP_HOST is required")
+	}
+	if cfg.Port <= 0 || cfg.Port > 65535 {
+		errors = append(errors, "SMTP_PORT must be within 1-65535")
+	}
+	switch cfg.Security {
+	case "none", "starttls", "tls":
+	default:
+		errors = append(errors, "SMTP_SECURITY must be 'none', 'starttls' or 'tls'")
+	}
+	if cfg.PoolSize <= 0 {
+		errors = append(errors, "SMTP_POOL_SIZE must be > 0")
+	}
+	if cfg.Password != "" && cfg.Username == "" {
+		errors = append(errors, "SMTP_USERNAME is required when SMTP_PASSWORD is set")
+	}
+	return errors
+}
@@ -2,9 +2,13 @@ package app
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"net/netip"
 	"os"
 	"os/signal"
 	"syscall"
@@ -15,6 +19,11 @@ import (
 	"notification-service/internal/handlers"
 	"notification-service/internal/infrastructure"
 	"notification-service/internal/notifier"
+	"notification-service/internal/repository/deliveries"
+	"notification-service/internal/repository/emailevents"
+	"notification-service/internal/repository/suppressions"
+	"notification-service/internal/repository/webhooks"
+	"notification-service/internal/templates"
 
 	"github.com/sendgrid/sendgrid-go"
 )
@@ -23,6 +32,156 @@ type eventHandlerManager interface {
 	Handle(message []byte) error
 }
 
+const (
+	migrationsPath   = "internal/migrations"
+	dbConnectTimeout = 10 * time.Second
+	httpReadTimeout  = 10 * time.Second
+	httpWriteTimeout = 10 * time.Second
+	httpIdleTimeout  = 60 * time.Second
+)
+
+type emailNotifierManager interface {
+	Send(to, subject, textBody, htmlBody string) (domain.SendResult, error)
+}
+
+type smsSenderManager interface {
+	SendSMS(to, body string) (domain.SendResult, error)
+}
+
+// newSMSSender builds the backend selected by SMS_PROVIDER, or nil when SMS
+// delivery is disabled.
+func newSMSSender(cfg config.Config) smsSenderManager {
+	switch cfg.SMS.Provider {
+	case config.SMSProviderTwilio:
+		log.Printf("[APP] Using Twilio SMS backend at %s", cfg.SMS.APIURL)
+		return infrastructure.NewTwilioSMSSender(
+			&http.Client{},
+			cfg.SMS.APIURL,
+			cfg.SMS.AccountSID,
+			cfg.SMS.AuthToken,
+			cfg.SMS.From,
+		)
+	case config.SMSProviderFake:
+		log.Println("[APP] Using fake SMS backend")
+		return infrastructure.NewFakeSMSSender()
+	default:
+		log.Println("[APP] SMS delivery disabled")
+		return nil
+	}
+}
+
+// newTelegramNotifier returns nil when no bot token is configured.
+func newTelegramNotifier(cfg config.Config) emailNotifierManager {
+	if cfg.Telegram.BotToken == "" {
+		log.Println("[APP] Telegram delivery disabled")
+		return nil
+	}
+	log.Printf("[APP] Using Telegram Bot API at %s", cfg.Telegram.APIURL)
+	return infrastructure.NewTelegramNotifier(&http.Client{}, cfg.Telegram.APIURL, cfg.Telegram.BotToken)
+}
+
+type webhookSenderManager interface {
+	Deliver(url, event string, data any) (domain.SendResult, error)
+}
+
+// newWebhookNotifier returns nil when no signing secret is configured.
+func newWebhookNotifier(cfg config.Config, store *webhooks.Repository) webhookSenderManager {
+	if cfg.Webhook.SigningSecret == "" {
+		log.Println("[APP] Webhook delivery disabled")
+		return nil
+	}
+	allowed := make([]netip.Prefix, 0, len(cfg.Webhook.AllowedNetworks))
+	for _, network := range cfg.Webhook.AllowedNetworks {
+		// Already validated when the config was loaded.
+		allowed = append(allowed, netip.MustParsePrefix(network))
+	}
+	return infrastructure.NewWebhookNotifier(
+		infrastructure.NewWebhookHTTPClient(cfg.Webhook.Timeout, allowed),
+		store,
+		infrastructure.WebhookOptions{
+			Secret:       cfg.Webhook.SigningSecret,
+			Timeout:      cfg.Webhook.Timeout,
+			DisableAfter: cfg.Webhook.DisableAfter,
+		},
+	)
+}
+
+type dedupStoreManager interface {
+	Seen(ctx context.Context, key string) (bool, error)
+	Mark(ctx context.Context, key string) error
+}
+
+// newDedupStore builds the store selected by DEDUP_STORE, or nil when dedup is
+// disabled, and returns a func releasing its resources.
+func newDedupStore(cfg config.Config) (dedupStoreManager, func() error, error) {
+	switch cfg.Dedup.Store {
+	case config.DedupStoreRedis:
+		addr := fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port)
+		log.Printf("[APP] Using Redis dedup store at %s (window %s)", addr, cfg.Dedup.Window)
+		store, err := infrastructure.NewRedisDedupStore(addr, cfg.Redis.Password, cfg.Redis.DB, cfg.Dedup.Window)
+		if err != nil {
+			return nil, nil, err
+		}
+		return store, store.Close, nil
+	case config.DedupStoreMemory:
+		log.Printf("[APP] Using in-memory dedup store (window %s)", cfg.Dedup.Window)
+		return infrastructure.NewMemoryDedupStore(cfg.Dedup.Window), func() error { return nil }, nil
+	default:
+		log.Println("[APP] Event dedup disabled")
+		return nil, func() error { return nil }, nil
+	}
+}
+
+// dedupKeyOf returns the key identifying an event for dedup, or "" for events
+// published without an event_id.
+func dedupKeyOf(topic string, message []byte) string {
+	var envelope struct {
+		EventID string `json:"event_id"`
+	}
+	if err := json.Unmarshal(message, &envelope); err != nil || envelope.EventID == "" {
+		return ""
+	}
+	return topic + ":" + envelope.EventID
+}
+
+// newSendgridWebhookKey parses SENDGRID_WEBHOOK_PUBLIC_KEY, or returns nil
+// when the event webhook is disabled.
+func newSendgridWebhookKey(cfg config.Config) (*ecdsa.PublicKey, error) {
+	if cfg.SendGrid.WebhookPublicKey == "" {
+		log.Println("[APP] SendGrid event webhook disabled")
+		return nil, nil
+	}
+	key, err := infrastructure.ParseSendgridPublicKey(cfg.SendGrid.WebhookPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SENDGRID_WEBHOOK_PUBLIC_KEY: %w", err)
+	}
+	return key, nil
+}
+
+// newEmailNotifier builds the backend selected by EMAIL_PROVIDER and returns a
+// func releasing its resources.
+func newEmailNotifier(cfg config.Config) (emailNotifierManager, func() error) {
+	if cfg.Email.Provider == config.EmailProviderSMTP {
+		log.Printf("[APP] Using SMTP email backend at %s:%d", cfg.SMTP.Host, cfg.SMTP.Port)
+		smtpNotifier := infrastructure.NewSMTPNotifier(infrastructure.SMTPOptions{
+			Host:        cfg.SMTP.Host,
+			Port:        cfg.SMTP.Port,
+			Username:    cfg.SMTP.Username,
+			Password:    cfg.SMTP.Password,
+			Security:    cfg.SMTP.Security,
+			PoolSize:    cfg.SMTP.PoolSize,
+			DialTimeout: cfg.SMTP.DialTimeout,
+			IdleTimeout: cfg.SMTP.IdleTimeout,
+		}, cfg.Email.SenderName, cfg.Email.SenderEmail)
+		return smtpNotifier, smtpNotifier.Close
+	}
+
+	log.Println("[APP] Using SendGrid email backend")
+	sgClient := sendgrid.NewSendClient(cfg.SendGrid.APIKey)
+	sendgridNotifier := infrastructure.NewSendgridNotifier(sgClient, cfg.Email.SenderName, cfg.Email.SenderEmail)
+	return sendgridNotifier, func() error { return nil }
+}
+
 func Run(ctx context.Context) error {
 	cfg, err := config.MustLoad()
 	if err != nil {
@@ -31,17 +190,84 @@ func Run(ctx context.Context) error {
 
 	log.Printf("[APP] Notification Service starting on port %d...", cfg.Server.Port)
 
-	sgClient := sendgrid.NewSendClient(cfg.SendGrid.APIKey)
-	sendgridNotifier := infrastructure.NewSendgridNotifier(sgClient, cfg.SendGrid.SenderName, cfg.SendGrid.SenderEmail)
+	emailNotifier, closeNotifier := newEmailNotifier(cfg)
+	defer func() {
+		if err := closeNotifier(); err != nil {
+			log.Printf("[APP] Email notifier close error: %v", err)
+		}
+	}()
 
-	templateRepo := domain.NewTemplateRepository()
+	dbCtx, cancelDB := context.WithTimeout(ctx, dbConnectTimeout)
+	db, err := infrastructure.OpenDB(dbCtx, cfg.Database.DSN())
+	cancelDB()
+	if err != nil {
+		return fmt.Errorf("failed to init db: %w", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Printf("[APP] DB close error: %v", err)
+		}
+	}()
+	if err := infrastructure.RunMigrations(db, migrationsPath); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+	deliveryRepo := deliveries.New(db)
+	suppressionRepo := suppressions.New(db)
+	webhookRepo := webhooks.New(db)
 
-	notificationService := notifier.NewService(sendgridNotifier, templateRepo)
+	sendgridKey, err := newSendgridWebhookKey(cfg)
+	if err != nil {
+		return err
+	}
+
+	templateStore, err := templates.NewStore(cfg.Templates.Dir, cfg.Templates.Versions)
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	auditPublisher := infrastructure.NewNotificationEventPublisher(
+		cfg.Kafka.Brokers,
+		cfg.Kafka.NotificationSentTopic,
+		cfg.Kafka.NotificationFailedTopic,
+		cfg.Kafka.SuppressionTopic,
+	)
+	defer func() {
+		if err := auditPublisher.Close(); err != nil {
+			log.Printf("[APP] Audit publisher close error: %v", err)
+		}
+	}()
+
+	notificationService := notifier.NewService(
+		emailNotifier,
+		newSMSSender(cfg),
+		newTelegramNotifier(cfg),
+		newWebhookNotifier(cfg, webhookRepo),
+		templateStore,
+		auditPublisher,
+		deliveryRepo,
+		suppressionRepo,
+	)
 
 	eventHandlers := map[string]eventHandlerManager{
-		"weather.updated":        handlers.NewWeatherUpdateHandler(notificationService),
-		"subscription.confirmed": handlers.NewSubscriptionConfirmedHandler(notificationService),
-		"subscription.cancelled": handlers.NewSubscriptionCancelledHandler(notificationService),
+		"weather.updated":          handlers.NewWeatherUpdateHandler(notificationService),
+		"subscription.created":     handlers.NewSubscriptionCreatedHandler(notificationService),
+		"subscription.confirmed":   handlers.NewSubscriptionConfirmedHandler(notificationService, cfg.Kafka.LegacyConfirmedEvents),
+		"subscription.cancelled":   handlers.NewSubscriptionCancelledHandler(notificationService),
+		"subscription.updated":     handlers.NewSubscriptionUpdatedHandler(notificationService),
+		"subscription.paused":      handlers.NewSubscriptionPausedHandler(notificationService),
+		"subscription.resumed":     handlers.NewSubscriptionResumedHandler(notificationService),
+		"subscription.manage_link": handlers.NewManageLinkHandler(notificationService),
+	}
+
+	if cfg.Audit.LogPath != "" {
+		auditLog, err := infrastructure.NewFileAuditLog(cfg.Audit.LogPath)
+		if err != nil {
+			return fmt.Errorf("failed to open audit log: %w", err)
+		}
+		log.Printf("[APP] Recording notification audit events to %s", cfg.Audit.LogPath)
+		auditHandler := handlers.NewNotificationAuditHandler(auditLog)
+		eventHandlers[cfg.Kafka.NotificationSentTopic] = auditHandler
+		eventHandlers[cfg.Kafka.NotificationFailedTopic] = auditHandler
 	}
 
 	topics := make([]string, 0, len(eventHandlers))
@@ -49,24 +275,62 @@ func Run(ctx context.Context) error {
 		topics = append(topics, topic)
 	}
 
+	dedupStore, closeDedupStore, err := newDedupStore(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init dedup store: %w", err)
+	}
+	defer func() {
+		if err := closeDedupStore(); err != nil {
+			log.Printf("[APP] Dedup store close error: %v", err)
+		}
+	}()
+
 	messageHandler := func(ctx context.Context, topic string, message []byte) error {
 		log.Printf("[APP] Topic: %s, Message: %s", topic, string(message))
 		if handler, ok := eventHandlers[topic]; ok {
+			dedupKey := ""
+			if dedupStore != nil {
+				dedupKey = dedupKeyOf(topic, message)
+			}
+			if dedupKey != "" {
+				// A failing store must not block delivery; a rare duplicate is
+				// preferable to a lost notification.
+				seen, err := dedupStore.Seen(ctx, dedupKey)
+				if err != nil {
+					log.Printf("[APP] Dedup check failed for %s: %v", dedupKey, err)
+				} else if seen {
+					log.Printf("[APP] Skipping duplicate event %s", dedupKey)
+					return nil
+				}
+			}
 			if err := handler.Handle(message); err != nil {
 				log.Printf("[APP] Handler error for topic %s: %v", topic, err)
 				return fmt.Errorf("handler error for topic %s: %w", topic, err)
 			}
+			if dedupKey != "" {
+				if err := dedupStore.Mark(ctx, dedupKey); err != nil {
+					log.Printf("[APP] Failed to mark event %s as processed: %v", dedupKey, err)
+				}
+			}
 			return nil
 		}
 		log.Printf("[APP] No handler found for topic: %s", topic)
 		return nil
 	}
 
+	deadLetters := infrastructure.NewDeadLetterPublisher(cfg.Kafka.Brokers, cfg.Kafka.DeadLetterTopic)
+	defer func() {
+		if err := deadLetters.Close(); err != nil {
+			log.Printf("[APP] Dead letter publisher close error: %v", err)
+		}
+	}()
+
 	consumer := infrastructure.NewKafkaConsumer(
 		cfg.Kafka.Brokers,
 		topics,
 		"notification-service",
 		messageHandler,
+		deadLetters,
 	)
 
 	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
@@ -74,14 +338,69 @@ func Run(ctx context.Context) error {
 
 	consumerDone := consumer.Start(ctx)
 
+	mux := http.NewServeMux()
+	if sendgridKey != nil {
+		mux.Handle("POST /webhooks/sendgrid", handlers.NewSendgridEventHandler(
+			sendgridKey,
+			emailevents.New(db),
+			suppressionRepo,
+			auditPublisher,
+		))
+	}
+	if cfg.Admin.Token != "" {
+		mux.Handle("GET /notifications", handlers.RequireAdminToken(cfg.Admin.Token, handlers.NewDeliveryLogHandler(deliveryRepo)))
+		admin := handlers.NewSuppressionAdminHandler(suppressionRepo)
+		mux.Handle("GET /suppressions", handlers.RequireAdminToken(cfg.Admin.Token, http.HandlerFunc(admin.Get)))
+		mux.Handle("POST /suppressions", handlers.RequireAdminToken(cfg.Admin.Token, http.HandlerFunc(admin.Create)))
+		mux.Handle("DELETE /suppressions", handlers.RequireAdminToken(cfg.Admin.Token, http.HandlerFunc(admin.Delete)))
+		webhookAdmin := handlers.NewWebhookEndpointAdminHandler(webhookRepo)
+		mux.Handle("GET /webhook-endpoints", handlers.RequireAdminToken(cfg.Admin.Token, http.HandlerFunc(webhookAdmin.List)))
+		mux.Handle("DELETE /webhook-endpoints", handlers.RequireAdminToken(cfg.Admin.Token, http.HandlerFunc(webhookAdmin.Enable)))
+	} else {
+		log.Println("[APP] Delivery history, suppression and webhook endpoint admin API disabled")
+	}
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	})
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler:      mux,
+		ReadTimeout:  httpReadTimeout,
+		WriteTimeout: httpWriteTimeout,
+		IdleTimeout:  httpIdleTimeout,
+	}
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("[APP] HTTP server listening on %s", server.Addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+			stop()
+		}
+	}()
+
+	if cfg.Templates.ReloadInterval > 0 {
+		go templateStore.StartHotReload(ctx, cfg.Templates.ReloadInterval)
+	}
+
 	log.Println("[APP] Notification Service is running. Waiting for events...")
 
 	<-ctx.Done()
 	log.Println("[APP] Notification Service shutting down...")
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.GracefulShutdownTimeout)
 	defer cancel()
 
+	log.Println("[APP] Shutting down HTTP server...")
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("[APP] HTTP server shutdown error: %v", err)
+	}
+	select {
+	case err := <-serverErr:
+		log.Printf("[APP] HTTP server error: %v", err)
+	default:
+	}
+
 	log.Println("[APP] Waiting for Kafka consumer to finish...")
 	select {
 		case <-consumerDone:
@@ -1,21 +1,210 @@
 package domain
 
-type MessageTemplate struct {
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+const (
+	ConfirmTemplate       = "confirm"
+	WelcomeTemplate       = "welcome"
+	WeatherUpdateTemplate = "weather_update"
+	UnsubscribeTemplate   = "unsubscribe"
+
+	SubscriptionUpdatedTemplate = "subscription_updated"
+	SubscriptionPausedTemplate  = "subscription_paused"
+	SubscriptionResumedTemplate = "subscription_resumed"
+
+	ManageLinkTemplate = "manage_link"
+)
+
+// MaxSMSLength is the length of a single-segment SMS; SMS templates must
+// render within it.
+const MaxSMSLength = 160
+
+// SMSTemplate returns the name of the SMS variant of an email template.
+func SMSTemplate(name string) string {
+	return name + ".sms"
+}
+
+// RenderedMessage is a template rendered for one recipient. HTML is empty
+// when the template has no HTML part.
+type RenderedMessage struct {
 	Subject string
-	Message string
+	Text    string
+	HTML    string
+}
+
+type ConfirmationData struct {
+	ConfirmToken string `json:"confirm_token"`
 }
 
+type WelcomeData struct {
+	City             string `json:"city"`
+	UnsubscribeToken string `json:"unsubscribe_token"`
+}
+
+type UnsubscribeData struct {
+	City string `json:"city"`
+}
+
+// ManageLinkData carries the signed link to the manage portal; ExpiresAt is
+// already formatted for display.
+type ManageLinkData struct {
+	Link      string `json:"link"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// SubscriptionChangeData acknowledges an update, pause or resume. Schedule is
+// a human-readable description such as "daily at 08:00 (Europe/Kyiv)".
+type SubscriptionChangeData struct {
+	City     string `json:"city"`
+	Schedule string `json:"schedule"`
+}
+
+const (
+	NotificationSentEventType   = "notification.sent"
+	NotificationFailedEventType = "notification.failed"
+)
+
+// NotificationSentEvent records one send attempt for auditing. Recipients are
+// stored only as HashRecipient digests.
 type NotificationSentEvent struct {
-	NotificationID string
-	ChannelType    string
-	Recipient      string
-	Status         string
-	SentAt         int64
+	NotificationID string `json:"event_id"`
+	EventType      string `json:"event_type"`
+	ChannelType    string `json:"channel_type"`
+	RecipientHash  string `json:"recipient_hash"`
+	Template       string `json:"template"`
+	Provider       string `json:"provider,omitempty"`
+	ResponseCode   int    `json:"response_code,omitempty"`
+	Error          string `json:"error,omitempty"`
+	LatencyMs      int64  `json:"latency_ms"`
+	SentAt         int64  `json:"sent_at"`
+}
+
+// HashRecipient returns the audit identifier of a recipient: the hex SHA-256
+// of the address, trimmed and lower-cased.
+func HashRecipient(recipient string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(recipient))))
+	return hex.EncodeToString(sum[:])
+}
+
+// SendResult describes a message accepted by a provider: the ID it assigned
+// and the status code it answered with, where the protocol has them.
+type SendResult struct {
+	MessageID  string
+	StatusCode int
+}
+
+// ProviderError is returned by senders when a provider rejects a message
+// with an HTTP status code.
+type ProviderError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ProviderError) Error() string {
+	return e.Message
+}
+
+// PermanentError wraps a failure that redelivering the event cannot fix, so
+// the consumer dead-letters the event without retrying it.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
 }
 
 type WeatherMetrics struct {
-	City        string
-	Description string
-	Temperature float64
-	Humidity    float64
+	City        string  `json:"city"`
+	Description string  `json:"description"`
+	Temperature float64 `json:"temperature"`
+	Humidity    float64 `json:"humidity"`
+}
+
+const (
+	DeliveryStatusSent    = "sent"
+	DeliveryStatusFailed  = "failed"
+	DeliveryStatusSkipped = "skipped"
+)
+
+// Delivery is one entry of the notification delivery log. Attempts counts
+// the send attempts made for the same source event.
+type Delivery struct {
+	ID                int64      `json:"id"`
+	EventID           string     `json:"event_id,omitempty"`
+	ChannelType       string     `json:"channel_type"`
+	RecipientHash     string     `json:"recipient_hash"`
+	Template          string     `json:"template"`
+	Provider          string     `json:"provider,omitempty"`
+	ProviderMessageID string     `json:"provider_message_id,omitempty"`
+	Status            string     `json:"status"`
+	Attempts          int        `json:"attempts"`
+	Error             string     `json:"error,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	SentAt            *time.Time `json:"sent_at,omitempty"`
+}
+
+const (
+	RecipientSuppressedEventType = "recipient.suppressed"
+
+	SuppressionReasonBounce     = "bounce"
+	SuppressionReasonSpamReport = "spamreport"
+
+	SuppressionSourceSendgrid = "sendgrid"
+	SuppressionSourceManual   = "manual"
+)
+
+// Suppression blocks every notification to a recipient until ExpiresAt, or
+// indefinitely when ExpiresAt is nil.
+type Suppression struct {
+	RecipientHash string     `json:"recipient_hash"`
+	ChannelType   string     `json:"channel_type"`
+	Reason        string     `json:"reason"`
+	Source        string     `json:"source"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+}
+
+// EmailEvent is a bounce or spam report received from the email provider.
+type EmailEvent struct {
+	ProviderEventID   string
+	ProviderMessageID string
+	Email             string
+	Event             string
+	BounceType        string
+	Reason            string
+	OccurredAt        time.Time
+}
+
+// RecipientSuppressedEvent tells subscribers that a recipient can no longer
+// be notified, e.g. after a hard bounce or spam complaint.
+type RecipientSuppressedEvent struct {
+	EventID      string `json:"event_id"`
+	EventType    string `json:"event_type"`
+	ChannelType  string `json:"channel_type"`
+	ChannelValue string `json:"channel_value"`
+	Reason       string `json:"reason"`
+	Source       string `json:"source"`
+	OccurredAt   int64  `json:"occurred_at"`
+}
+
+// WebhookEndpoint is the failure state of a webhook URL. Endpoints are
+// tracked from their first failed delivery; a nil DisabledAt means the
+// endpoint still receives deliveries.
+type WebhookEndpoint struct {
+	URL                 string     `json:"url"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	UpdatedAt           time.Time  `json:"updated_at"`
 }
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/repository/deliveries"
+)
+
+const (
+	deliveryLookupTimeout = 5 * time.Second
+	defaultDeliveryLimit  = 100
+	maxDeliveryLimit      = 1000
+	dateLayout            = "2006-01-02"
+)
+
+type deliveryRepositoryManager interface {
+	List(ctx context.Context, q deliveries.Query) ([]domain.Delivery, error)
+}
+
+type DeliveryLogResponse struct {
+	Recipient     string            `json:"recipient"`
+	Notifications []domain.Delivery `json:"notifications"`
+}
+
+// DeliveryLogHandler serves GET /notifications?recipient=&from=&to=&limit=.
+// from and to accept RFC 3339 timestamps or UTC days; a day given as to is
+// included in full.
+type DeliveryLogHandler struct {
+	repo deliveryRepositoryManager
+}
+
+func NewDeliveryLogHandler(repo deliveryRepositoryManager) *DeliveryLogHandler {
+	return &DeliveryLogHandler{repo: repo}
+}
+
+func (h *DeliveryLogHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	recipient := params.Get("recipient")
+	if recipient == "" {
+		http.Error(w, "recipient is required", http.StatusBadRequest)
+		return
+	}
+	query := deliveries.Query{
+		RecipientHash: domain.HashRecipient(recipient),
+		Limit:         defaultDeliveryLimit,
+	}
+	var err error
+	if query.From, err = parseTimeBound(params.Get("from"), false); err != nil {
+		http.Error(w, "invalid from: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if query.To, err = parseTimeBound(params.Get("to"), true); err != nil {
+		http.Error(w, "invalid to: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if limit := params.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 || n > maxDeliveryLimit {
+			http.Error(w, fmt.Sprintf("limit must be between 1 and %d", maxDeliveryLimit), http.StatusBadRequest)
+			return
+		}
+		query.Limit = n
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), deliveryLookupTimeout)
+	defer cancel()
+	result, err := h.repo.List(ctx, query)
+	if err != nil {
+		log.Printf("[DeliveryLogHandler] Failed to list deliveries: %v", err)
+		http.Error(w, "failed to list notifications", http.StatusInternalServerError)
+		return
+	}
+	if result == nil {
+		result = []domain.Delivery{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(DeliveryLogResponse{
+		Recipient:     recipient,
+		Notifications: result,
+	}); err != nil {
+		log.Printf("[DeliveryLogHandler] Failed to write response: %v", err)
+	}
+}
+
+func parseTimeBound(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD or RFC 3339 timestamp")
+	}
+	if end {
+		return day.AddDate(0, 0, 1), nil
+	}
+	return day, nil
+}
@@ -0,0 +1,64 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/handlers"
+	"notification-service/internal/repository/deliveries"
+)
+
+type stubDeliveryRepo struct {
+	query  deliveries.Query
+	result []domain.Delivery
+}
+
+func (s *stubDeliveryRepo) List(_ context.Context, q deliveries.Query) ([]domain.Delivery, error) {
+	s.query = q
+	return s.result, nil
+}
+
+func TestDeliveryLogHandler_QueriesByRecipientHashAndDays(t *testing.T) {
+	repo := &stubDeliveryRepo{result: []domain.Delivery{{ID: 7, Status: domain.DeliveryStatusSent}}}
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/notifications?recipient=user@example.com&from=2025-06-10&to=2025-06-10", nil)
+
+	handlers.NewDeliveryLogHandler(repo).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	if repo.query.RecipientHash != domain.HashRecipient("user@example.com") {
+		t.Errorf("recipient hash = %q", repo.query.RecipientHash)
+	}
+	wantFrom := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	if !repo.query.From.Equal(wantFrom) || !repo.query.To.Equal(wantFrom.AddDate(0, 0, 1)) {
+		t.Errorf("range = %s..%s, want the whole of 2025-06-10", repo.query.From, repo.query.To)
+	}
+	var body handlers.DeliveryLogResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Notifications) != 1 || body.Notifications[0].ID != 7 {
+		t.Errorf("notifications = %+v", body.Notifications)
+	}
+}
+
+func TestDeliveryLogHandler_RejectsBadInput(t *testing.T) {
+	for _, target := range []string{
+		"/notifications",
+		"/notifications?recipient=a@b.c&from=yesterday",
+		"/notifications?recipient=a@b.c&limit=0",
+	} {
+		rec := httptest.NewRecorder()
+		handlers.NewDeliveryLogHandler(&stubDeliveryRepo{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", target, rec.Code)
+		}
+	}
+}
@@ -3,14 +3,24 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
+	"log"
 	"notification-service/internal/domain"
 	"notification-service/internal/notifier"
+	"time"
 )
 
 const (
 	emailChannel = "email"
 )
 
+// channelOf defaults to email for events published before channel_type was set.
+func channelOf(channelType string) string {
+	if channelType == "" {
+		return emailChannel
+	}
+	return channelType
+}
+
 func parseEvent[T any](message []byte) (T, error) {
 	var event T
 	if err := json.Unmarshal(message, &event); err != nil {
@@ -20,19 +30,34 @@ func parseEvent[T any](message []byte) (T, error) {
 }
 
 type WeatherUpdateEvent struct {
+	EventID   string				`json:"event_id"`
 	Metrics   domain.WeatherMetrics	`json:"metrics"`
 	UpdatedAt int64					`json:"updated_at"`
-	Email     string				`json:"channel_value"`
+	Recipient   string				`json:"channel_value"`
+	ChannelType string				`json:"channel_type"`
+}
+
+type SubscriptionCreatedEvent struct {
+	EventID     string `json:"event_id"`
+	Recipient   string `json:"channel_value"`
+	ChannelType string `json:"channel_type"`
+	Token       string `json:"token"`
 }
 
 type SubscriptionConfirmedEvent struct {
-	Email string `json:"channel_value"`
-	Token string `json:"token"`
+	EventID     string `json:"event_id"`
+	Recipient   string `json:"channel_value"`
+	ChannelType string `json:"channel_type"`
+	City        string `json:"city"`
+	Token       string `json:"token"`
+	ConfirmedAt int64  `json:"confirmed_at"`
 }
 
 type SubscriptionCancelledEvent struct {
-	Email string `json:"channel_value"`
-	City  string `json:"city"`
+	EventID     string `json:"event_id"`
+	Recipient   string `json:"channel_value"`
+	ChannelType string `json:"channel_type"`
+	City        string `json:"city"`
 }
 
 type WeatherUpdateHandler struct {
@@ -50,16 +75,39 @@ func (h *WeatherUpdateHandler) Handle(message []byte) error {
 	if err != nil {
 		return err
 	}
-	return h.notificationService.SendWeatherUpdate(emailChannel, event.Email, event.Metrics)
+	return h.notificationService.ForEvent(event.EventID).SendWeatherUpdate(channelOf(event.ChannelType), event.Recipient, event.Metrics)
+}
+
+type SubscriptionCreatedHandler struct {
+	notificationService *notifier.Service
+}
+
+func NewSubscriptionCreatedHandler(service *notifier.Service) *SubscriptionCreatedHandler {
+	return &SubscriptionCreatedHandler{
+		notificationService: service,
+	}
+}
+
+func (h *SubscriptionCreatedHandler) Handle(message []byte) error {
+	event, err := parseEvent[SubscriptionCreatedEvent](message)
+	if err != nil {
+		return err
+	}
+	return h.notificationService.ForEvent(event.EventID).SendConfirmation(channelOf(event.ChannelType), event.Recipient, event.Token)
 }
 
 type SubscriptionConfirmedHandler struct {
 	notificationService *notifier.Service
+	legacyMode          bool
 }
 
-func NewSubscriptionConfirmedHandler(service *notifier.Service) *SubscriptionConfirmedHandler {
+// NewSubscriptionConfirmedHandler builds the welcome-email handler. With legacyMode
+// enabled, events published before subscription.created existed (no confirmed_at)
+// are still treated as confirmation-code requests so in-flight messages are not lost.
+func NewSubscriptionConfirmedHandler(service *notifier.Service, legacyMode bool) *SubscriptionConfirmedHandler {
 	return &SubscriptionConfirmedHandler{
 		notificationService: service,
+		legacyMode:          legacyMode,
 	}
 }
 
@@ -68,7 +116,15 @@ func (h *SubscriptionConfirmedHandler) Handle(message []byte) error {
 	if err != nil {
 		return err
 	}
-	return h.notificationService.SendConfirmation(emailChannel, event.Email, event.Token)
+	if event.ConfirmedAt == 0 && event.Token != "" {
+		if h.legacyMode {
+			log.Printf("[SubscriptionConfirmedHandler] legacy event for %s: sending confirmation code", event.Recipient)
+			return h.notificationService.ForEvent(event.EventID).SendConfirmation(channelOf(event.ChannelType), event.Recipient, event.Token)
+		}
+		log.Printf("[SubscriptionConfirmedHandler] skipping legacy event for %s: legacy mode disabled", event.Recipient)
+		return nil
+	}
+	return h.notificationService.ForEvent(event.EventID).SendWelcome(channelOf(event.ChannelType), event.Recipient, event.City, event.Token)
 }
 
 type SubscriptionCancelledHandler struct {
@@ -86,5 +142,164 @@ func (h *SubscriptionCancelledHandler) Handle(message []byte) error {
 	if err != nil {
 		return err
 	}
-	return h.notificationService.SendUnsubscribe(emailChannel, event.Email, event.City)
+	return h.notificationService.ForEvent(event.EventID).SendUnsubscribe(channelOf(event.ChannelType), event.Recipient, event.City)
+}
+
+// SubscriptionChangedEvent is published for subscription.updated,
+// subscription.paused and subscription.resumed.
+type SubscriptionChangedEvent struct {
+	EventID          string `json:"event_id"`
+	Recipient        string `json:"channel_value"`
+	ChannelType      string `json:"channel_type"`
+	City             string `json:"city"`
+	FrequencyMinutes int    `json:"frequency_minutes"`
+	DeliveryTime     string `json:"delivery_time"`
+	DeliveryDays     string `json:"delivery_days"`
+	Timezone         string `json:"timezone"`
+}
+
+// describeSchedule renders a subscription schedule for acknowledgement
+// messages, e.g. "every hour", "daily at 08:00 (Europe/Kyiv)" or
+// "at 07:30 on Sat,Sun (Europe/Kyiv)".
+func describeSchedule(event SubscriptionChangedEvent) string {
+	minutes := event.FrequencyMinutes
+	zone := ""
+	if event.Timezone != "" {
+		zone = " (" + event.Timezone + ")"
+	}
+	switch {
+	case minutes == 60:
+		return "every hour"
+	case minutes == 24*60 && event.DeliveryTime != "" && event.DeliveryDays == "Mon-Fri":
+		return fmt.Sprintf("on weekdays at %s%s", event.DeliveryTime, zone)
+	case minutes == 24*60 && event.DeliveryTime != "" && event.DeliveryDays != "":
+		return fmt.Sprintf("at %s on %s%s", event.DeliveryTime, event.DeliveryDays, zone)
+	case minutes == 24*60 && event.DeliveryTime != "":
+		return fmt.Sprintf("daily at %s%s", event.DeliveryTime, zone)
+	case minutes == 24*60:
+		return "daily"
+	case minutes > 0 && minutes%60 == 0:
+		return fmt.Sprintf("every %d hours", minutes/60)
+	case minutes > 0:
+		return fmt.Sprintf("every %d minutes", minutes)
+	default:
+		return "on your schedule"
+	}
+}
+
+type SubscriptionUpdatedHandler struct {
+	notificationService *notifier.Service
+}
+
+func NewSubscriptionUpdatedHandler(service *notifier.Service) *SubscriptionUpdatedHandler {
+	return &SubscriptionUpdatedHandler{
+		notificationService: service,
+	}
+}
+
+func (h *SubscriptionUpdatedHandler) Handle(message []byte) error {
+	event, err := parseEvent[SubscriptionChangedEvent](message)
+	if err != nil {
+		return err
+	}
+	return h.notificationService.ForEvent(event.EventID).SendSubscriptionUpdated(
+		channelOf(event.ChannelType), event.Recipient, event.City, describeSchedule(event))
+}
+
+type SubscriptionPausedHandler struct {
+	notificationService *notifier.Service
+}
+
+func NewSubscriptionPausedHandler(service *notifier.Service) *SubscriptionPausedHandler {
+	return &SubscriptionPausedHandler{
+		notificationService: service,
+	}
+}
+
+func (h *SubscriptionPausedHandler) Handle(message []byte) error {
+	event, err := parseEvent[SubscriptionChangedEvent](message)
+	if err != nil {
+		return err
+	}
+	return h.notificationService.ForEvent(event.EventID).SendSubscriptionPaused(
+		channelOf(event.ChannelType), event.Recipient, event.City)
+}
+
+type SubscriptionResumedHandler struct {
+	notificationService *notifier.Service
+}
+
+func NewSubscriptionResumedHandler(service *notifier.Service) *SubscriptionResumedHandler {
+	return &SubscriptionResumedHandler{
+		notificationService: service,
+	}
+}
+
+func (h *SubscriptionResumedHandler) Handle(message []byte) error {
+	event, err := parseEvent[SubscriptionChangedEvent](message)
+	if err != nil {
+		return err
+	}
+	return h.notificationService.ForEvent(event.EventID).SendSubscriptionResumed(
+		channelOf(event.ChannelType), event.Recipient, event.City, describeSchedule(event))
+}
+
+// ManageLinkEvent is published for subscription.manage_link when a recipient
+// with subscriptions asks for a manage-portal link.
+type ManageLinkEvent struct {
+	EventID       string `json:"event_id"`
+	Recipient     string `json:"channel_value"`
+	ChannelType   string `json:"channel_type"`
+	Link          string `json:"link"`
+	LinkExpiresAt int64  `json:"link_expires_at"`
+}
+
+type ManageLinkHandler struct {
+	notificationService *notifier.Service
+}
+
+func NewManageLinkHandler(service *notifier.Service) *ManageLinkHandler {
+	return &ManageLinkHandler{
+		notificationService: service,
+	}
+}
+
+func (h *ManageLinkHandler) Handle(message []byte) error {
+	event, err := parseEvent[ManageLinkEvent](message)
+	if err != nil {
+		return err
+	}
+	if time.Now().Unix() >= event.LinkExpiresAt {
+		log.Printf("[ManageLinkHandler] skipping expired manage link for event %s", event.EventID)
+		return nil
+	}
+	return h.notificationService.ForEvent(event.EventID).SendManageLink(
+		channelOf(event.ChannelType), event.Recipient, event.Link, time.Unix(event.LinkExpiresAt, 0))
+}
+
+type auditStoreManager interface {
+	Append(event domain.NotificationSentEvent) error
+}
+
+// NotificationAuditHandler stores notification.sent and notification.failed
+// events so support can look up what was delivered to a recipient.
+type NotificationAuditHandler struct {
+	store auditStoreManager
+}
+
+func NewNotificationAuditHandler(store auditStoreManager) *NotificationAuditHandler {
+	return &NotificationAuditHandler{
+		store: store,
+	}
+}
+
+func (h *NotificationAuditHandler) Handle(message []byte) error {
+	event, err := parseEvent[domain.NotificationSentEvent](message)
+	if err != nil {
+		return err
+	}
+	if err := h.store.Append(event); err != nil {
+		return fmt.Errorf("failed to store audit event %s: %w", event.NotificationID, err)
+	}
+	return nil
 }
@@ -0,0 +1,90 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/handlers"
+	"notification-service/internal/notifier"
+)
+
+// recordingRenderer renders every template to a message whose subject and
+// text carry the template name and data.
+type recordingRenderer struct {
+	rendered []string
+	data     []any
+}
+
+func (r *recordingRenderer) Render(name string, data any) (domain.RenderedMessage, error) {
+	r.rendered = append(r.rendered, name)
+	r.data = append(r.data, data)
+	return domain.RenderedMessage{Subject: name, Text: name}, nil
+}
+
+type recordingEmailSender struct {
+	recipients []string
+}
+
+func (s *recordingEmailSender) Send(to, _, _, _ string) (domain.SendResult, error) {
+	s.recipients = append(s.recipients, to)
+	return domain.SendResult{}, nil
+}
+
+func newConfirmedHandler(legacyMode bool) (*handlers.SubscriptionConfirmedHandler, *recordingRenderer, *recordingEmailSender) {
+	renderer := &recordingRenderer{}
+	email := &recordingEmailSender{}
+	service := notifier.NewService(email, nil, nil, nil, renderer, nil, nil, nil)
+	return handlers.NewSubscriptionConfirmedHandler(service, legacyMode), renderer, email
+}
+
+func TestSubscriptionConfirmedHandler(t *testing.T) {
+	const (
+		legacyEvent = `{"event_id":"e1","channel_value":"user@example.com","channel_type":"email","city":"Kyiv","token":"tok-1"}`
+		newEvent    = `{"event_id":"e2","channel_value":"user@example.com","channel_type":"email","city":"Kyiv","token":"tok-1","confirmed_at":1749556800}`
+	)
+	tests := []struct {
+		name         string
+		legacyMode   bool
+		message      string
+		wantTemplate string
+	}{
+		{name: "legacy event in legacy mode", legacyMode: true, message: legacyEvent, wantTemplate: domain.ConfirmTemplate},
+		{name: "legacy event with legacy mode disabled", message: legacyEvent},
+		{name: "confirmed event", message: newEvent, wantTemplate: domain.WelcomeTemplate},
+		{name: "confirmed event in legacy mode", legacyMode: true, message: newEvent, wantTemplate: domain.WelcomeTemplate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, renderer, email := newConfirmedHandler(tt.legacyMode)
+
+			if err := h.Handle([]byte(tt.message)); err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+
+			if tt.wantTemplate == "" {
+				if len(email.recipients) != 0 {
+					t.Errorf("sent %d emails, want none", len(email.recipients))
+				}
+				return
+			}
+			if len(renderer.rendered) != 1 || renderer.rendered[0] != tt.wantTemplate {
+				t.Fatalf("rendered %v, want [%s]", renderer.rendered, tt.wantTemplate)
+			}
+			if len(email.recipients) != 1 || email.recipients[0] != "user@example.com" {
+				t.Errorf("recipients = %v, want [user@example.com]", email.recipients)
+			}
+			switch data := renderer.data[0].(type) {
+			case domain.ConfirmationData:
+				if data.ConfirmToken != "tok-1" {
+					t.Errorf("confirm token = %q, want tok-1", data.ConfirmToken)
+				}
+			case domain.WelcomeData:
+				if data.City != "Kyiv" || data.UnsubscribeToken != "tok-1" {
+					t.Errorf("welcome data = %+v", data)
+				}
+			default:
+				t.Errorf("unexpected template data %T", data)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/infrastructure"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxSendgridEventsBody   = 1 << 20
+	sendgridEventTimeout    = 10 * time.Second
+	sendgridBounceBlocked   = "blocked"
+	sendgridEventBounce     = "bounce"
+	sendgridEventSpamReport = "spamreport"
+)
+
+type emailEventStoreManager interface {
+	Record(ctx context.Context, e domain.EmailEvent) error
+}
+
+type suppressionStoreManager interface {
+	Add(ctx context.Context, s domain.Suppression) error
+}
+
+type suppressionPublisherManager interface {
+	PublishSuppression(event domain.RecipientSuppressedEvent) error
+}
+
+type sendgridEvent struct {
+	Email       string `json:"email"`
+	Timestamp   int64  `json:"timestamp"`
+	Event       string `json:"event"`
+	Type        string `json:"type"`
+	Reason      string `json:"reason"`
+	SGEventID   string `json:"sg_event_id"`
+	SGMessageID string `json:"sg_message_id"`
+}
+
+// SendgridEventHandler ingests SendGrid's signed event webhook. Hard bounces
+// and spam reports add the recipient to the suppression list, are recorded and
+// are published as recipient.suppressed; other event types are acknowledged
+// and ignored.
+type SendgridEventHandler struct {
+	key          *ecdsa.PublicKey
+	store        emailEventStoreManager
+	suppressions suppressionStoreManager
+	publisher    suppressionPublisherManager
+}
+
+func NewSendgridEventHandler(
+	key *ecdsa.PublicKey,
+	store emailEventStoreManager,
+	suppressions suppressionStoreManager,
+	publisher suppressionPublisherManager,
+) *SendgridEventHandler {
+	return &SendgridEventHandler{
+		key:          key,
+		store:        store,
+		suppressions: suppressions,
+		publisher:    publisher,
+	}
+}
+
+func (h *SendgridEventHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSendgridEventsBody))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if err := infrastructure.VerifySendgridSignature(
+		h.key,
+		r.Header.Get(infrastructure.SendgridSignatureHeader),
+		r.Header.Get(infrastructure.SendgridTimestampHeader),
+		body,
+	); err != nil {
+		log.Printf("[SendgridEventHandler] Rejected event batch: %v", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var events []sendgridEvent
+	if err := json.Unmarshal(body, &events); err != nil {
+		http.Error(w, "invalid event payload", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), sendgridEventTimeout)
+	defer cancel()
+	// Any failure answers 5xx so SendGrid redelivers the batch; suppressing,
+	// recording and publishing are all idempotent per sg_event_id.
+	for _, e := range events {
+		if err := h.handle(ctx, e); err != nil {
+			log.Printf("[SendgridEventHandler] Failed to handle event %s: %v", e.SGEventID, err)
+			http.Error(w, "failed to process events", http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *SendgridEventHandler) handle(ctx context.Context, e sendgridEvent) error {
+	reason := suppressionReasonOf(e)
+	if reason == "" || e.Email == "" {
+		return nil
+	}
+	eventID := e.SGEventID
+	if eventID == "" {
+		eventID = uuid.NewString()
+	}
+	occurredAt := time.Unix(e.Timestamp, 0)
+
+	log.Printf("[SendgridEventHandler] Suppressing %s after %s: %s", e.Email, reason, e.Reason)
+	if err := h.suppressions.Add(ctx, domain.Suppression{
+		RecipientHash: domain.HashRecipient(e.Email),
+		ChannelType:   emailChannel,
+		Reason:        reason,
+		Source:        domain.SuppressionSourceSendgrid,
+	}); err != nil {
+		return err
+	}
+	if err := h.publisher.PublishSuppression(domain.RecipientSuppressedEvent{
+		EventID:      eventID,
+		EventType:    domain.RecipientSuppressedEventType,
+		ChannelType:  emailChannel,
+		ChannelValue: e.Email,
+		Reason:       reason,
+		Source:       domain.SuppressionSourceSendgrid,
+		OccurredAt:   occurredAt.Unix(),
+	}); err != nil {
+		return err
+	}
+	return h.store.Record(ctx, domain.EmailEvent{
+		ProviderEventID:   eventID,
+		ProviderMessageID: e.SGMessageID,
+		Email:             e.Email,
+		Event:             e.Event,
+		BounceType:        e.Type,
+		Reason:            e.Reason,
+		OccurredAt:        occurredAt,
+	})
+}
+
+// suppressionReasonOf returns the suppression reason for hard bounces and spam
+// reports, or "" for events that do not warrant suppression. Blocked bounces
+// are temporary and ignored.
+func suppressionReasonOf(e sendgridEvent) string {
+	switch e.Event {
+	case sendgridEventBounce:
+		if e.Type == sendgridBounceBlocked {
+			return ""
+		}
+		return domain.SuppressionReasonBounce
+	case sendgridEventSpamReport:
+		return domain.SuppressionReasonSpamReport
+	default:
+		return ""
+	}
+}
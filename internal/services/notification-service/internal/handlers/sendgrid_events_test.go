@@ -0,0 +1,153 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/handlers"
+	"notification-service/internal/infrastructure"
+)
+
+type stubEmailEventStore struct {
+	recorded []domain.EmailEvent
+}
+
+func (s *stubEmailEventStore) Record(_ context.Context, e domain.EmailEvent) error {
+	s.recorded = append(s.recorded, e)
+	return nil
+}
+
+type stubSuppressionStore struct {
+	added []domain.Suppression
+}
+
+func (s *stubSuppressionStore) Add(_ context.Context, suppression domain.Suppression) error {
+	s.added = append(s.added, suppression)
+	return nil
+}
+
+type stubSuppressionPublisher struct {
+	published []domain.RecipientSuppressedEvent
+}
+
+func (s *stubSuppressionPublisher) PublishSuppression(event domain.RecipientSuppressedEvent) error {
+	s.published = append(s.published, event)
+	return nil
+}
+
+const sendgridBatch = `[
+	{"email":"gone@example.com","timestamp":1749549600,"event":"bounce","type":"bounce","reason":"550 no such user","sg_event_id":"ev-1","sg_message_id":"msg-1"},
+	{"email":"busy@example.com","timestamp":1749549600,"event":"bounce","type":"blocked","sg_event_id":"ev-2"},
+	{"email":"angry@example.com","timestamp":1749549600,"event":"spamreport","sg_event_id":"ev-3"},
+	{"email":"ok@example.com","timestamp":1749549600,"event":"delivered","sg_event_id":"ev-4"}
+]`
+
+func signedSendgridRequest(t *testing.T, key *ecdsa.PrivateKey, body string) *http.Request {
+	t.Helper()
+	return signedSendgridRequestAt(t, key, body, time.Now())
+}
+
+func signedSendgridRequestAt(t *testing.T, key *ecdsa.PrivateKey, body string, signedAt time.Time) *http.Request {
+	t.Helper()
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	digest := sha256.Sum256([]byte(timestamp + body))
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/sendgrid", bytes.NewBufferString(body))
+	req.Header.Set(infrastructure.SendgridSignatureHeader, base64.StdEncoding.EncodeToString(sig))
+	req.Header.Set(infrastructure.SendgridTimestampHeader, timestamp)
+	return req
+}
+
+func TestSendgridEventHandler_SuppressesHardBouncesAndSpamReports(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	store := &stubEmailEventStore{}
+	suppressions := &stubSuppressionStore{}
+	publisher := &stubSuppressionPublisher{}
+	rec := httptest.NewRecorder()
+
+	handlers.NewSendgridEventHandler(&key.PublicKey, store, suppressions, publisher).
+		ServeHTTP(rec, signedSendgridRequest(t, key, sendgridBatch))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	if len(publisher.published) != 2 || len(store.recorded) != 2 {
+		t.Fatalf("published %d, recorded %d events; want 2 each", len(publisher.published), len(store.recorded))
+	}
+	bounce := publisher.published[0]
+	if bounce.EventID != "ev-1" || bounce.ChannelValue != "gone@example.com" ||
+		bounce.Reason != domain.SuppressionReasonBounce || bounce.EventType != domain.RecipientSuppressedEventType {
+		t.Errorf("bounce event = %+v", bounce)
+	}
+	if publisher.published[1].Reason != domain.SuppressionReasonSpamReport {
+		t.Errorf("spam report reason = %q", publisher.published[1].Reason)
+	}
+	if len(suppressions.added) != 2 || suppressions.added[0].RecipientHash != domain.HashRecipient("gone@example.com") ||
+		suppressions.added[0].ExpiresAt != nil {
+		t.Errorf("suppressions = %+v, want a permanent entry per suppressed recipient", suppressions.added)
+	}
+	if store.recorded[0].ProviderMessageID != "msg-1" || store.recorded[0].Reason != "550 no such user" {
+		t.Errorf("recorded event = %+v", store.recorded[0])
+	}
+}
+
+func TestSendgridEventHandler_RejectsBadSignature(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	publisher := &stubSuppressionPublisher{}
+	rec := httptest.NewRecorder()
+
+	handlers.NewSendgridEventHandler(&key.PublicKey, &stubEmailEventStore{}, &stubSuppressionStore{}, publisher).
+		ServeHTTP(rec, signedSendgridRequest(t, other, sendgridBatch))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", rec.Code)
+	}
+	if len(publisher.published) != 0 {
+		t.Errorf("published %d events for an unsigned batch", len(publisher.published))
+	}
+}
+
+func TestSendgridEventHandler_RejectsStaleTimestamp(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	for _, offset := range []time.Duration{-time.Hour, time.Hour} {
+		publisher := &stubSuppressionPublisher{}
+		rec := httptest.NewRecorder()
+
+		handlers.NewSendgridEventHandler(&key.PublicKey, &stubEmailEventStore{}, &stubSuppressionStore{}, publisher).
+			ServeHTTP(rec, signedSendgridRequestAt(t, key, sendgridBatch, time.Now().Add(offset)))
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("offset %v: status = %d, want 401", offset, rec.Code)
+		}
+		if len(publisher.published) != 0 {
+			t.Errorf("offset %v: published %d events for a replayed batch", offset, len(publisher.published))
+		}
+	}
+}
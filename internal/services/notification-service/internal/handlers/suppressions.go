@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/notifier"
+	"notification-service/internal/repository/suppressions"
+)
+
+const (
+	suppressionLookupTimeout = 5 * time.Second
+	maxSuppressionBody       = 64 << 10
+)
+
+type suppressionRepositoryManager interface {
+	Add(ctx context.Context, s domain.Suppression) error
+	Lookup(ctx context.Context, recipientHash string) (*domain.Suppression, error)
+	Remove(ctx context.Context, recipientHash string) error
+}
+
+// SuppressionRequest adds a recipient to the suppression list. A nil
+// ExpiresAt suppresses the recipient until the entry is removed.
+type SuppressionRequest struct {
+	Recipient   string     `json:"recipient"`
+	ChannelType string     `json:"channel_type"`
+	Reason      string     `json:"reason"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// SuppressionAdminHandler manages the suppression list by hand:
+// GET, POST and DELETE /suppressions, identifying the recipient by the
+// recipient query parameter or request body.
+type SuppressionAdminHandler struct {
+	repo suppressionRepositoryManager
+}
+
+func NewSuppressionAdminHandler(repo suppressionRepositoryManager) *SuppressionAdminHandler {
+	return &SuppressionAdminHandler{repo: repo}
+}
+
+func (h *SuppressionAdminHandler) Get(w http.ResponseWriter, r *http.Request) {
+	recipient := r.URL.Query().Get("recipient")
+	if recipient == "" {
+		http.Error(w, "recipient is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), suppressionLookupTimeout)
+	defer cancel()
+	suppression, err := h.repo.Lookup(ctx, domain.HashRecipient(recipient))
+	if err != nil {
+		log.Printf("[SuppressionAdminHandler] Failed to look up suppression: %v", err)
+		http.Error(w, "failed to look up suppression", http.StatusInternalServerError)
+		return
+	}
+	if suppression == nil {
+		http.Error(w, suppressions.ErrNotFound.Error(), http.StatusNotFound)
+		return
+	}
+	writeSuppression(w, http.StatusOK, suppression)
+}
+
+func (h *SuppressionAdminHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req SuppressionRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSuppressionBody)).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.Recipient = strings.TrimSpace(req.Recipient)
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Recipient == "" || req.Reason == "" {
+		http.Error(w, "recipient and reason are required", http.StatusBadRequest)
+		return
+	}
+	switch req.ChannelType {
+	case notifier.EmailChannel, notifier.SMSChannel, notifier.TelegramChannel, notifier.WebhookChannel:
+	default:
+		http.Error(w, "channel_type must be 'email', 'sms', 'telegram' or 'webhook'", http.StatusBadRequest)
+		return
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		http.Error(w, "expires_at must be in the future", http.StatusBadRequest)
+		return
+	}
+
+	suppression := domain.Suppression{
+		RecipientHash: domain.HashRecipient(req.Recipient),
+		ChannelType:   req.ChannelType,
+		Reason:        req.Reason,
+		Source:        domain.SuppressionSourceManual,
+		CreatedAt:     time.Now().UTC(),
+		ExpiresAt:     req.ExpiresAt,
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), suppressionLookupTimeout)
+	defer cancel()
+	if err := h.repo.Add(ctx, suppression); err != nil {
+		log.Printf("[SuppressionAdminHandler] Failed to add suppression: %v", err)
+		http.Error(w, "failed to add suppression", http.StatusInternalServerError)
+		return
+	}
+	log.Printf("[SuppressionAdminHandler] Suppressed %s recipient %s: %s",
+		suppression.ChannelType, suppression.RecipientHash, suppression.Reason)
+	writeSuppression(w, http.StatusCreated, &suppression)
+}
+
+func (h *SuppressionAdminHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	recipient := r.URL.Query().Get("recipient")
+	if recipient == "" {
+		http.Error(w, "recipient is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), suppressionLookupTimeout)
+	defer cancel()
+	err := h.repo.Remove(ctx, domain.HashRecipient(recipient))
+	if errors.Is(err, suppressions.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("[SuppressionAdminHandler] Failed to remove suppression: %v", err)
+		http.Error(w, "failed to remove suppression", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeSuppression(w http.ResponseWriter, status int, suppression *domain.Suppression) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(suppression); err != nil {
+		log.Printf("[SuppressionAdminHandler] Failed to write response: %v", err)
+	}
+}
+
+// RequireAdminToken rejects requests without "Authorization: Bearer <token>".
+func RequireAdminToken(token string, next http.Handler) http.Handler {
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
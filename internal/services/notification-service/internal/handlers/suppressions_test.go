@@ -0,0 +1,103 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/handlers"
+	"notification-service/internal/repository/suppressions"
+)
+
+type stubSuppressionRepo struct {
+	entries map[string]domain.Suppression
+}
+
+func (s *stubSuppressionRepo) Add(_ context.Context, suppression domain.Suppression) error {
+	s.entries[suppression.RecipientHash] = suppression
+	return nil
+}
+
+func (s *stubSuppressionRepo) Lookup(_ context.Context, recipientHash string) (*domain.Suppression, error) {
+	if suppression, ok := s.entries[recipientHash]; ok {
+		return &suppression, nil
+	}
+	return nil, nil
+}
+
+func (s *stubSuppressionRepo) Remove(_ context.Context, recipientHash string) error {
+	if _, ok := s.entries[recipientHash]; !ok {
+		return suppressions.ErrNotFound
+	}
+	delete(s.entries, recipientHash)
+	return nil
+}
+
+func TestSuppressionAdminHandler_AddLookupRemove(t *testing.T) {
+	repo := &stubSuppressionRepo{entries: map[string]domain.Suppression{}}
+	admin := handlers.NewSuppressionAdminHandler(repo)
+
+	rec := httptest.NewRecorder()
+	admin.Create(rec, httptest.NewRequest(http.MethodPost, "/suppressions", bytes.NewBufferString(
+		`{"recipient":"+380501234567","channel_type":"sms","reason":"asked to stop"}`)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, body = %s", rec.Code, rec.Body)
+	}
+	added := repo.entries[domain.HashRecipient("+380501234567")]
+	if added.Source != domain.SuppressionSourceManual || added.Reason != "asked to stop" {
+		t.Errorf("added = %+v", added)
+	}
+
+	rec = httptest.NewRecorder()
+	admin.Get(rec, httptest.NewRequest(http.MethodGet, "/suppressions?recipient=%2B380501234567", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("get status = %d", rec.Code)
+	}
+
+	for _, want := range []int{http.StatusNoContent, http.StatusNotFound} {
+		rec = httptest.NewRecorder()
+		admin.Delete(rec, httptest.NewRequest(http.MethodDelete, "/suppressions?recipient=%2B380501234567", nil))
+		if rec.Code != want {
+			t.Errorf("delete status = %d, want %d", rec.Code, want)
+		}
+	}
+}
+
+func TestSuppressionAdminHandler_RejectsBadInput(t *testing.T) {
+	for _, body := range []string{
+		`{"recipient":"a@b.c","channel_type":"email"}`,
+		`{"recipient":"a@b.c","channel_type":"fax","reason":"x"}`,
+		`{"recipient":"a@b.c","channel_type":"email","reason":"x","expires_at":"2001-01-01T00:00:00Z"}`,
+	} {
+		rec := httptest.NewRecorder()
+		handlers.NewSuppressionAdminHandler(&stubSuppressionRepo{entries: map[string]domain.Suppression{}}).
+			Create(rec, httptest.NewRequest(http.MethodPost, "/suppressions", bytes.NewBufferString(body)))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", body, rec.Code)
+		}
+	}
+}
+
+func TestRequireAdminToken(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusTeapot) })
+	guarded := handlers.RequireAdminToken("s3cret", next)
+
+	for header, want := range map[string]int{
+		"":              http.StatusUnauthorized,
+		"Bearer wrong":  http.StatusUnauthorized,
+		"Bearer s3cret": http.StatusTeapot,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/suppressions", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		guarded.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("Authorization %q: status = %d, want %d", header, rec.Code, want)
+		}
+	}
+}
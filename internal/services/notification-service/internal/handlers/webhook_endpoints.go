@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/repository/webhooks"
+)
+
+const webhookEndpointLookupTimeout = 5 * time.Second
+
+type webhookEndpointRepositoryManager interface {
+	List(ctx context.Context) ([]domain.WebhookEndpoint, error)
+	Enable(ctx context.Context, url string) error
+}
+
+// WebhookEndpointAdminHandler exposes the failure state of webhook endpoints:
+// GET /webhook-endpoints lists endpoints with failed deliveries, and
+// DELETE /webhook-endpoints?url= forgets the failures of one, re-enabling it.
+type WebhookEndpointAdminHandler struct {
+	repo webhookEndpointRepositoryManager
+}
+
+func NewWebhookEndpointAdminHandler(repo webhookEndpointRepositoryManager) *WebhookEndpointAdminHandler {
+	return &WebhookEndpointAdminHandler{repo: repo}
+}
+
+func (h *WebhookEndpointAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), webhookEndpointLookupTimeout)
+	defer cancel()
+	endpoints, err := h.repo.List(ctx)
+	if err != nil {
+		log.Printf("[WebhookEndpointAdminHandler] Failed to list webhook endpoints: %v", err)
+		http.Error(w, "failed to list webhook endpoints", http.StatusInternalServerError)
+		return
+	}
+	if endpoints == nil {
+		endpoints = []domain.WebhookEndpoint{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(endpoints); err != nil {
+		log.Printf("[WebhookEndpointAdminHandler] Failed to write response: %v", err)
+	}
+}
+
+func (h *WebhookEndpointAdminHandler) Enable(w http.ResponseWriter, r *http.Request) {
+	url := r.URL.Query().Get("url")
+	if url == "" {
+		http.Error(w, "url is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), webhookEndpointLookupTimeout)
+	defer cancel()
+	err := h.repo.Enable(ctx, url)
+	if errors.Is(err, webhooks.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("[WebhookEndpointAdminHandler] Failed to enable webhook endpoint: %v", err)
+		http.Error(w, "failed to enable webhook endpoint", http.StatusInternalServerError)
+		return
+	}
+	log.Printf("[WebhookEndpointAdminHandler] Re-enabled webhook endpoint %s", url)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,75 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/handlers"
+	"notification-service/internal/repository/webhooks"
+)
+
+type stubWebhookEndpointRepo struct {
+	endpoints map[string]domain.WebhookEndpoint
+}
+
+func (s *stubWebhookEndpointRepo) List(context.Context) ([]domain.WebhookEndpoint, error) {
+	var result []domain.WebhookEndpoint
+	for _, e := range s.endpoints {
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+func (s *stubWebhookEndpointRepo) Enable(_ context.Context, url string) error {
+	if _, ok := s.endpoints[url]; !ok {
+		return webhooks.ErrNotFound
+	}
+	delete(s.endpoints, url)
+	return nil
+}
+
+func TestWebhookEndpointAdminHandler_ListAndEnable(t *testing.T) {
+	disabledAt := time.Now().UTC()
+	repo := &stubWebhookEndpointRepo{endpoints: map[string]domain.WebhookEndpoint{
+		"https://hooks.example.com/a": {
+			URL:                 "https://hooks.example.com/a",
+			ConsecutiveFailures: 5,
+			LastError:           "endpoint returned status code 500",
+			DisabledAt:          &disabledAt,
+		},
+	}}
+	admin := handlers.NewWebhookEndpointAdminHandler(repo)
+
+	rec := httptest.NewRecorder()
+	admin.List(rec, httptest.NewRequest(http.MethodGet, "/webhook-endpoints", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list status = %d", rec.Code)
+	}
+	var listed []domain.WebhookEndpoint
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(listed) != 1 || listed[0].DisabledAt == nil || listed[0].ConsecutiveFailures != 5 {
+		t.Errorf("listed = %+v, want one disabled endpoint", listed)
+	}
+
+	for _, want := range []int{http.StatusNoContent, http.StatusNotFound} {
+		rec = httptest.NewRecorder()
+		admin.Enable(rec, httptest.NewRequest(http.MethodDelete,
+			"/webhook-endpoints?url=https%3A%2F%2Fhooks.example.com%2Fa", nil))
+		if rec.Code != want {
+			t.Errorf("enable status = %d, want %d", rec.Code, want)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	admin.Enable(rec, httptest.NewRequest(http.MethodDelete, "/webhook-endpoints", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("enable without url status = %d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,101 @@
+package infrastructure
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"notification-service/internal/domain"
+)
+
+// AuditQuery filters audit entries. Zero From/To leave that bound open; an
+// empty RecipientHash matches every recipient.
+type AuditQuery struct {
+	RecipientHash string
+	From          time.Time
+	To            time.Time
+}
+
+// FileAuditLog stores notification audit events as JSON lines in an
+// append-only file.
+type FileAuditLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileAuditLog(path string) (*FileAuditLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	return &FileAuditLog{path: path}, nil
+}
+
+func (l *FileAuditLog) Append(event domain.NotificationSentEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return f.Close()
+}
+
+// Query returns the entries matching q in the order they were recorded.
+func (l *FileAuditLog) Query(q AuditQuery) ([]domain.NotificationSentEvent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var events []domain.NotificationSentEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event domain.NotificationSentEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("failed to parse audit log entry: %w", err)
+		}
+		if q.matches(event) {
+			events = append(events, event)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return events, nil
+}
+
+func (q AuditQuery) matches(event domain.NotificationSentEvent) bool {
+	if q.RecipientHash != "" && event.RecipientHash != q.RecipientHash {
+		return false
+	}
+	sentAt := time.Unix(event.SentAt, 0)
+	if !q.From.IsZero() && sentAt.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !sentAt.Before(q.To) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package infrastructure_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/infrastructure"
+)
+
+func TestFileAuditLog_QueryByRecipientAndDay(t *testing.T) {
+	auditLog, err := infrastructure.NewFileAuditLog(filepath.Join(t.TempDir(), "audit", "notifications.jsonl"))
+	if err != nil {
+		t.Fatalf("NewFileAuditLog: %v", err)
+	}
+
+	tuesday := time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+	user := domain.HashRecipient("User@Example.com ")
+	entries := []domain.NotificationSentEvent{
+		{NotificationID: "1", EventType: domain.NotificationSentEventType, RecipientHash: user, SentAt: tuesday.Unix()},
+		{NotificationID: "2", EventType: domain.NotificationSentEventType, RecipientHash: domain.HashRecipient("other@example.com"), SentAt: tuesday.Unix()},
+		{NotificationID: "3", EventType: domain.NotificationFailedEventType, RecipientHash: user, SentAt: tuesday.Add(24 * time.Hour).Unix()},
+	}
+	for _, e := range entries {
+		if err := auditLog.Append(e); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	day := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	got, err := auditLog.Query(infrastructure.AuditQuery{
+		RecipientHash: domain.HashRecipient("user@example.com"),
+		From:          day,
+		To:            day.Add(24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(got) != 1 || got[0].NotificationID != "1" {
+		t.Fatalf("Query = %+v, want only entry 1", got)
+	}
+}
+
+func TestFileAuditLog_QueryMissingFile(t *testing.T) {
+	auditLog, err := infrastructure.NewFileAuditLog(filepath.Join(t.TempDir(), "notifications.jsonl"))
+	if err != nil {
+		t.Fatalf("NewFileAuditLog: %v", err)
+	}
+	got, err := auditLog.Query(infrastructure.AuditQuery{})
+	if err != nil || len(got) != 0 {
+		t.Fatalf("Query = %v, %v; want no entries", got, err)
+	}
+}
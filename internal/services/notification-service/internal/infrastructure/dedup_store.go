@@ -0,0 +1,102 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	dedupKeyPrefix         = "notification:dedup:"
+	redisConnectionTimeout = 5 * time.Second
+)
+
+// MemoryDedupStore remembers processed event keys for the dedup window. State
+// is per instance and resets on restart, so it only suits single-replica runs.
+type MemoryDedupStore struct {
+	mu     sync.Mutex
+	window time.Duration
+	seen   map[string]time.Time
+}
+
+func NewMemoryDedupStore(window time.Duration) *MemoryDedupStore {
+	return &MemoryDedupStore{
+		window: window,
+		seen:   make(map[string]time.Time),
+	}
+}
+
+func (s *MemoryDedupStore) Seen(_ context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expiresAt, ok := s.seen[key]
+	return ok && time.Now().Before(expiresAt), nil
+}
+
+func (s *MemoryDedupStore) Mark(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for k, expiresAt := range s.seen {
+		if !now.Before(expiresAt) {
+			delete(s.seen, k)
+		}
+	}
+	s.seen[key] = now.Add(s.window)
+	return nil
+}
+
+type redisDedupClientManager interface {
+	Exists(ctx context.Context, keys ...string) *redis.IntCmd
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) *redis.StatusCmd
+	Close() error
+}
+
+// RedisDedupStore shares processed event keys between replicas; keys expire
+// after the dedup window.
+type RedisDedupStore struct {
+	client redisDedupClientManager
+	window time.Duration
+}
+
+func NewRedisDedupStore(addr, password string, db int, window time.Duration) (*RedisDedupStore, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisConnectionTimeout)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &RedisDedupStore{
+		client: client,
+		window: window,
+	}, nil
+}
+
+func (s *RedisDedupStore) Seen(ctx context.Context, key string) (bool, error) {
+	n, err := s.client.Exists(ctx, dedupKeyPrefix+key).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check dedup key: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (s *RedisDedupStore) Mark(ctx context.Context, key string) error {
+	if err := s.client.Set(ctx, dedupKeyPrefix+key, 1, s.window).Err(); err != nil {
+		return fmt.Errorf("failed to set dedup key: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisDedupStore) Close() error {
+	return s.client.Close()
+}
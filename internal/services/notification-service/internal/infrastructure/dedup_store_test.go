@@ -0,0 +1,41 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"notification-service/internal/infrastructure"
+)
+
+func TestMemoryDedupStore_SeenAfterMark(t *testing.T) {
+	ctx := context.Background()
+	store := infrastructure.NewMemoryDedupStore(time.Minute)
+
+	seen, err := store.Seen(ctx, "weather.updated:1")
+	if err != nil || seen {
+		t.Fatalf("Seen before Mark = %v, %v; want false, nil", seen, err)
+	}
+	if err := store.Mark(ctx, "weather.updated:1"); err != nil {
+		t.Fatalf("Mark: %v", err)
+	}
+	if seen, _ := store.Seen(ctx, "weather.updated:1"); !seen {
+		t.Error("Seen after Mark = false, want true")
+	}
+	if seen, _ := store.Seen(ctx, "weather.updated:2"); seen {
+		t.Error("Seen for another key = true, want false")
+	}
+}
+
+func TestMemoryDedupStore_ExpiresAfterWindow(t *testing.T) {
+	ctx := context.Background()
+	store := infrastructure.NewMemoryDedupStore(10 * time.Millisecond)
+
+	if err := store.Mark(ctx, "subscription.created:1"); err != nil {
+		t.Fatalf("Mark: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if seen, _ := store.Seen(ctx, "subscription.created:1"); seen {
+		t.Error("Seen after window = true, want false")
+	}
+}
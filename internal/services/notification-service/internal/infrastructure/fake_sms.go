@@ -0,0 +1,44 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"notification-service/internal/domain"
+)
+
+type SentSMS struct {
+	To   string
+	Body string
+}
+
+// FakeSMSSender logs and records SMS instead of sending them, for local runs
+// and tests.
+type FakeSMSSender struct {
+	mu   sync.Mutex
+	sent []SentSMS
+}
+
+func NewFakeSMSSender() *FakeSMSSender {
+	return &FakeSMSSender{}
+}
+
+func (f *FakeSMSSender) Provider() string {
+	return "fake"
+}
+
+func (f *FakeSMSSender) SendSMS(to, body string) (domain.SendResult, error) {
+	log.Printf("[FakeSMSSender] SMS to %s: %s\n", to, body)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, SentSMS{To: to, Body: body})
+	return domain.SendResult{MessageID: fmt.Sprintf("fake-%d", len(f.sent))}, nil
+}
+
+func (f *FakeSMSSender) Sent() []SentSMS {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]SentSMS(nil), f.sent...)
+}
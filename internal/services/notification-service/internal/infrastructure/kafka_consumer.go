@@ -7,6 +7,8 @@ import (
 	"sync"
 	"time"
 
+	"notification-service/internal/domain"
+
 	"github.com/segmentio/kafka-go"
 )
 
@@ -21,19 +23,30 @@ const (
 
 type EventHandler func(ctx context.Context, topic string, message []byte) error
 
+type deadLetterPublisherManager interface {
+	PublishDeadLetter(ctx context.Context, m kafka.Message, attempts int, cause error) error
+}
+
 type KafkaConsumer struct {
-	brokers []string
-	topics  []string
-	groupID string
-	handler EventHandler
+	brokers     []string
+	topics      []string
+	groupID     string
+	handler     EventHandler
+	deadLetters deadLetterPublisherManager
 }
 
-func NewKafkaConsumer(brokers, topics []string, groupID string, handler EventHandler) *KafkaConsumer {
+func NewKafkaConsumer(
+	brokers, topics []string,
+	groupID string,
+	handler EventHandler,
+	deadLetters deadLetterPublisherManager,
+) *KafkaConsumer {
 	return &KafkaConsumer{
-		brokers: brokers,
-		topics:  topics,
-		groupID: groupID,
-		handler: handler,
+		brokers:     brokers,
+		topics:      topics,
+		groupID:     groupID,
+		handler:     handler,
+		deadLetters: deadLetters,
 	}
 }
 
@@ -110,9 +123,15 @@ func (c *KafkaConsumer) consumeTopic(ctx context.Context, topic string) error {
 		log.Printf("[KAFKA CONSUMER] received event from topic %s, partition %d, offset %d", topic, m.Partition, m.Offset)
 
 		if c.handler != nil {
-			if err := c.processWithRetry(ctx, topic, m.Value); err != nil {
-				log.Printf("[ERROR] handler failed for topic %s: %v", topic, err)
-				continue
+			if attempts, err := c.processWithRetry(ctx, topic, m.Value); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				log.Printf("[ERROR] handler failed for topic %s, moving offset %d to dead letter topic: %v", topic, m.Offset, err)
+				if err := c.deadLetters.PublishDeadLetter(ctx, m, attempts, err); err != nil {
+					log.Printf("[ERROR] failed to dead-letter message for topic %s: %v", topic, err)
+					return err
+				}
 			}
 		}
 
@@ -123,14 +142,19 @@ func (c *KafkaConsumer) consumeTopic(ctx context.Context, topic string) error {
 	}
 }
 
-func (c *KafkaConsumer) processWithRetry(ctx context.Context, topic string, msg []byte) error {
+func (c *KafkaConsumer) processWithRetry(ctx context.Context, topic string, msg []byte) (int, error) {
 	delay := delay
 	var lastErr error
 
 	for i := 0; i < maxHandlerRetryAttempts; i++ {
 		err := c.handler(ctx, topic, msg)
 		if err == nil {
-			return nil
+			return i + 1, nil
+		}
+		var permanent *domain.PermanentError
+		if errors.As(err, &permanent) {
+			log.Printf("[WARN] permanent handler error for topic %s, not retrying: %v", topic, err)
+			return i + 1, err
 		}
 		lastErr = err
 		log.Printf("[WARN] handler error (attempt %d/%d) for topic %s: %v", i+1, maxHandlerRetryAttempts, topic, err)
@@ -139,8 +163,8 @@ func (c *KafkaConsumer) processWithRetry(ctx context.Context, topic string, msg
 		case <-time.After(delay):
 			delay *= 2
 		case <-ctx.Done():
-			return ctx.Err()
+			return i + 1, ctx.Err()
 		}
 	}
-	return errors.New("max handler retry attempts reached: " + lastErr.Error())
+	return maxHandlerRetryAttempts, errors.New("max handler retry attempts reached: " + lastErr.Error())
 }
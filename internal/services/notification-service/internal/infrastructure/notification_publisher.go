@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"notification-service/internal/domain"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const notificationPublishTimeout = 3 * time.Second
+
+// NotificationEventPublisher publishes notification.sent and notification.failed
+// audit events and recipient.suppressed events, keyed by recipient hash so a
+// recipient's history stays ordered.
+type NotificationEventPublisher struct {
+	writer          messageWriterManager
+	sentTopic       string
+	failedTopic     string
+	suppressedTopic string
+}
+
+func NewNotificationEventPublisher(
+	brokers []string,
+	sentTopic, failedTopic, suppressedTopic string,
+) *NotificationEventPublisher {
+	return &NotificationEventPublisher{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Balancer: &kafka.Hash{},
+		},
+		sentTopic:       sentTopic,
+		failedTopic:     failedTopic,
+		suppressedTopic: suppressedTopic,
+	}
+}
+
+func (p *NotificationEventPublisher) PublishNotification(event domain.NotificationSentEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification event: %w", err)
+	}
+	topic := p.sentTopic
+	if event.EventType == domain.NotificationFailedEventType {
+		topic = p.failedTopic
+	}
+
+	return p.write(topic, event.RecipientHash, payload)
+}
+
+// PublishSuppression announces that a recipient must no longer be notified,
+// e.g. after a hard bounce or spam report.
+func (p *NotificationEventPublisher) PublishSuppression(event domain.RecipientSuppressedEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal suppression event: %w", err)
+	}
+	return p.write(p.suppressedTopic, domain.HashRecipient(event.ChannelValue), payload)
+}
+
+func (p *NotificationEventPublisher) write(topic, key string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), notificationPublishTimeout)
+	defer cancel()
+	if err := p.writer.WriteMessages(ctx, kafka.Message{
+		Topic: topic,
+		Key:   []byte(key),
+		Value: payload,
+		Time:  time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to publish event to %s: %w", topic, err)
+	}
+	return nil
+}
+
+func (p *NotificationEventPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 
+	"notification-service/internal/domain"
+
 	"github.com/sendgrid/rest"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
 )
@@ -30,26 +32,41 @@ func NewSendgridNotifier(
 	}
 }
 
-func (s *SendgridNotifier) Send(to, message, subject string) error {
+func (s *SendgridNotifier) Provider() string {
+	return "sendgrid"
+}
+
+// Send returns the X-Message-Id SendGrid assigned to the email.
+func (s *SendgridNotifier) Send(to, subject, textBody, htmlBody string) (domain.SendResult, error) {
 	from := mail.NewEmail(s.senderName, s.senderEmail)
 	toEmail := mail.NewEmail("", to)
-	m := mail.NewSingleEmail(from, subject, toEmail, message, message)
+	m := mail.NewSingleEmail(from, subject, toEmail, textBody, htmlBody)
 
 	log.Printf("[SendgridNotifier] Sending email to: %s, subject: %s\n", to, subject)
 
 	resp, err := s.client.Send(m)
 	if err != nil {
 		log.Printf("[SendgridNotifier] Error sending email to %s: %v\n", to, err)
-		return fmt.Errorf("failed to send email: %w", err)
+		return domain.SendResult{}, fmt.Errorf("failed to send email: %w", err)
 	}
 	if resp.StatusCode >= 300 {
 		log.Printf(
 			"[SendgridNotifier] Sendgrid returned error status code %d for %s. Response body: %s",
 			resp.StatusCode, to, resp.Body,
 		)
-		return fmt.Errorf("sendgrid returned error status code %d", resp.StatusCode)
+		return domain.SendResult{}, &domain.ProviderError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("sendgrid returned error status code %d", resp.StatusCode),
+		}
 	}
 
 	log.Printf("[SendgridNotifier] Email sent successfully to: %s\n", to)
-	return nil
+	return domain.SendResult{MessageID: sendgridMessageID(resp), StatusCode: resp.StatusCode}, nil
+}
+
+func sendgridMessageID(resp *rest.Response) string {
+	if ids := resp.Headers["X-Message-Id"]; len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
 }
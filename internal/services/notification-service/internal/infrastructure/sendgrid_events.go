@@ -0,0 +1,66 @@
+package infrastructure
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	SendgridSignatureHeader = "X-Twilio-Email-Event-Webhook-Signature"
+	SendgridTimestampHeader = "X-Twilio-Email-Event-Webhook-Timestamp"
+
+	// SendgridTimestampTolerance bounds how far the signed timestamp may be
+	// from now, so that captured requests cannot be replayed later.
+	SendgridTimestampTolerance = 10 * time.Minute
+)
+
+var (
+	ErrInvalidSendgridSignature = errors.New("invalid sendgrid event webhook signature")
+	ErrStaleSendgridTimestamp   = errors.New("sendgrid event webhook timestamp is outside the accepted window")
+)
+
+// ParseSendgridPublicKey decodes the base64 verification key shown in the
+// SendGrid signed event webhook settings.
+func ParseSendgridPublicKey(encoded string) (*ecdsa.PublicKey, error) {
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode sendgrid public key: %w", err)
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sendgrid public key: %w", err)
+	}
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("sendgrid public key is not an ECDSA key")
+	}
+	return ecKey, nil
+}
+
+// VerifySendgridSignature checks the ECDSA signature SendGrid computes over
+// the timestamp header followed by the raw request body, and rejects
+// timestamps more than SendgridTimestampTolerance away from now.
+func VerifySendgridSignature(key *ecdsa.PublicKey, signature, timestamp string, body []byte) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSendgridSignature
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSendgridSignature
+	}
+	digest := sha256.Sum256(append([]byte(timestamp), body...))
+	if !ecdsa.VerifyASN1(key, digest[:], sig) {
+		return ErrInvalidSendgridSignature
+	}
+	if age := time.Since(time.Unix(unix, 0)); age > SendgridTimestampTolerance || age < -SendgridTimestampTolerance {
+		return ErrStaleSendgridTimestamp
+	}
+	return nil
+}
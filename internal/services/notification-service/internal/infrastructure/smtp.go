@@ -0,0 +1,293 @@
+package infrastructure
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"notification-service/internal/domain"
+)
+
+const (
+	SMTPSecurityNone     = "none"
+	SMTPSecuritySTARTTLS = "starttls"
+	SMTPSecurityTLS      = "tls"
+)
+
+type SMTPOptions struct {
+	Host        string
+	Port        int
+	Username    string
+	Password    string
+	Security    string
+	PoolSize    int
+	DialTimeout time.Duration
+	IdleTimeout time.Duration
+}
+
+type smtpConn struct {
+	client   *smtp.Client
+	lastUsed time.Time
+}
+
+// SMTPNotifier delivers email over SMTP, keeping up to PoolSize authenticated
+// connections open between sends.
+type SMTPNotifier struct {
+	opts SMTPOptions
+	from mail.Address
+	idle chan *smtpConn
+	sem  chan struct{}
+}
+
+func NewSMTPNotifier(opts SMTPOptions, senderName, senderEmail string) *SMTPNotifier {
+	if opts.PoolSize <= 0 {
+		opts.PoolSize = 1
+	}
+	return &SMTPNotifier{
+		opts: opts,
+		from: mail.Address{Name: senderName, Address: senderEmail},
+		idle: make(chan *smtpConn, opts.PoolSize),
+		sem:  make(chan struct{}, opts.PoolSize),
+	}
+}
+
+func (s *SMTPNotifier) Provider() string {
+	return "smtp"
+}
+
+// Send returns the Message-ID header of the delivered email.
+func (s *SMTPNotifier) Send(to, subject, textBody, htmlBody string) (domain.SendResult, error) {
+	messageID := s.messageID()
+	msg, err := s.buildMessage(messageID, to, subject, textBody, htmlBody)
+	if err != nil {
+		return domain.SendResult{}, fmt.Errorf("failed to build email: %w", err)
+	}
+
+	log.Printf("[SMTPNotifier] Sending email to: %s, subject: %s\n", to, subject)
+
+	s.sem <- struct{}{}
+	defer func() { <-s.sem }()
+
+	conn, err := s.acquire()
+	if err != nil {
+		log.Printf("[SMTPNotifier] Error connecting to %s: %v\n", s.addr(), err)
+		return domain.SendResult{}, fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+
+	if err := s.deliver(conn.client, to, msg); err != nil {
+		_ = conn.client.Close()
+		log.Printf("[SMTPNotifier] Error sending email to %s: %v\n", to, err)
+		return domain.SendResult{}, fmt.Errorf("failed to send email: %w", err)
+	}
+	s.release(conn)
+
+	log.Printf("[SMTPNotifier] Email sent successfully to: %s\n", to)
+	return domain.SendResult{MessageID: messageID}, nil
+}
+
+// Close quits every idle pooled connection.
+func (s *SMTPNotifier) Close() error {
+	var errs []error
+	for {
+		select {
+		case conn := <-s.idle:
+			if err := conn.client.Quit(); err != nil {
+				errs = append(errs, err)
+			}
+		default:
+			return errors.Join(errs...)
+		}
+	}
+}
+
+func (s *SMTPNotifier) deliver(client *smtp.Client, to string, msg []byte) error {
+	if err := client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("MAIL FROM: %w", err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("RCPT TO: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("DATA: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to finish message: %w", err)
+	}
+	return nil
+}
+
+// acquire reuses an idle connection that is still alive, or dials a new one.
+func (s *SMTPNotifier) acquire() (*smtpConn, error) {
+	for {
+		select {
+		case conn := <-s.idle:
+			if s.opts.IdleTimeout > 0 && time.Since(conn.lastUsed) > s.opts.IdleTimeout {
+				_ = conn.client.Close()
+				continue
+			}
+			if err := conn.client.Reset(); err != nil {
+				_ = conn.client.Close()
+				continue
+			}
+			return conn, nil
+		default:
+			client, err := s.dial()
+			if err != nil {
+				return nil, err
+			}
+			return &smtpConn{client: client}, nil
+		}
+	}
+}
+
+func (s *SMTPNotifier) release(conn *smtpConn) {
+	conn.lastUsed = time.Now()
+	select {
+	case s.idle <- conn:
+	default:
+		_ = conn.client.Quit()
+	}
+}
+
+func (s *SMTPNotifier) dial() (*smtp.Client, error) {
+	dialer := &net.Dialer{Timeout: s.opts.DialTimeout}
+	tlsConfig := &tls.Config{ServerName: s.opts.Host, MinVersion: tls.VersionTLS12}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if s.opts.Security == SMTPSecurityTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", s.addr(), tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", s.addr())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, s.opts.Host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	if s.opts.Security == SMTPSecuritySTARTTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			_ = client.Close()
+			return nil, errors.New("smtp server does not support STARTTLS")
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			_ = client.Close()
+			return nil, fmt.Errorf("STARTTLS: %w", err)
+		}
+	}
+
+	if s.opts.Username != "" {
+		auth := smtp.PlainAuth("", s.opts.Username, s.opts.Password, s.opts.Host)
+		if err := client.Auth(auth); err != nil {
+			_ = client.Close()
+			return nil, fmt.Errorf("AUTH: %w", err)
+		}
+	}
+	return client, nil
+}
+
+func (s *SMTPNotifier) addr() string {
+	return net.JoinHostPort(s.opts.Host, strconv.Itoa(s.opts.Port))
+}
+
+// buildMessage renders an RFC 5322 message, using multipart/alternative when
+// an HTML part is present.
+func (s *SMTPNotifier) buildMessage(messageID, to, subject, textBody, htmlBody string) ([]byte, error) {
+	var buf bytes.Buffer
+	headers := textproto.MIMEHeader{}
+	headers.Set("From", s.from.String())
+	headers.Set("To", (&mail.Address{Address: to}).String())
+	headers.Set("Subject", mime.QEncoding.Encode("utf-8", subject))
+	headers.Set("Date", time.Now().Format(time.RFC1123Z))
+	headers.Set("Message-ID", messageID)
+	headers.Set("MIME-Version", "1.0")
+
+	if htmlBody == "" {
+		headers.Set("Content-Type", `text/plain; charset="utf-8"`)
+		headers.Set("Content-Transfer-Encoding", "quoted-printable")
+		writeHeaders(&buf, headers)
+		if err := writeQuotedPrintable(&buf, textBody); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{`text/plain; charset="utf-8"`, textBody},
+		{`text/html; charset="utf-8"`, htmlBody},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	headers.Set("Content-Type", fmt.Sprintf(`multipart/alternative; boundary="%s"`, mw.Boundary()))
+	writeHeaders(&buf, headers)
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
+
+func (s *SMTPNotifier) messageID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	domain := "localhost"
+	if at := strings.LastIndexByte(s.from.Address, '@'); at >= 0 {
+		domain = s.from.Address[at+1:]
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain)
+}
+
+func writeHeaders(buf *bytes.Buffer, headers textproto.MIMEHeader) {
+	for _, key := range []string{"From", "To", "Subject", "Date", "Message-ID", "MIME-Version", "Content-Type", "Content-Transfer-Encoding"} {
+		if v := headers.Get(key); v != "" {
+			fmt.Fprintf(buf, "%s: %s\r\n", key, v)
+		}
+	}
+	buf.WriteString("\r\n")
+}
+
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
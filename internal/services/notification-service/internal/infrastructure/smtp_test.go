@@ -0,0 +1,155 @@
+package infrastructure_test
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"notification-service/internal/infrastructure"
+)
+
+// fakeSMTPServer accepts plain SMTP sessions and records every message body
+// and how many connections were opened.
+type fakeSMTPServer struct {
+	listener net.Listener
+
+	mu          sync.Mutex
+	connections int
+	messages    []string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSMTPServer{listener: l}
+	t.Cleanup(func() { _ = l.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.connections++
+		s.mu.Unlock()
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 fake ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 fake")
+		case strings.HasPrefix(cmd, "DATA"):
+			reply("354 go ahead")
+			var body strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				body.WriteString(l)
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, body.String())
+			s.mu.Unlock()
+			reply("250 queued")
+		case strings.HasPrefix(cmd, "QUIT"):
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) snapshot() (int, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connections, append([]string(nil), s.messages...)
+}
+
+func TestSMTPNotifier_SendReusesPooledConnection(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	notifier := infrastructure.NewSMTPNotifier(infrastructure.SMTPOptions{
+		Host:        "127.0.0.1",
+		Port:        server.port(),
+		Security:    infrastructure.SMTPSecurityNone,
+		PoolSize:    1,
+		DialTimeout: time.Second,
+		IdleTimeout: time.Minute,
+	}, "Weather", "weather@example.com")
+	t.Cleanup(func() { _ = notifier.Close() })
+
+	result, err := notifier.Send("user@example.com", "Weather for Kyiv", "Sunny", "<p>Sunny</p>")
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if _, err := notifier.Send("user@example.com", "Confirm", "Code: 123", ""); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	connections, messages := server.snapshot()
+	if connections != 1 {
+		t.Errorf("connections = %d, want 1", connections)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("messages = %d, want 2", len(messages))
+	}
+	if result.MessageID == "" || !strings.Contains(messages[0], "Message-ID: "+result.MessageID) {
+		t.Errorf("message id %q not found in first message", result.MessageID)
+	}
+	if !strings.Contains(messages[0], "multipart/alternative") || !strings.Contains(messages[0], "<p>Sunny</p>") {
+		t.Errorf("first message is missing the html alternative:\n%s", messages[0])
+	}
+	if !strings.Contains(messages[1], "Subject: Confirm") || strings.Contains(messages[1], "multipart") {
+		t.Errorf("second message should be plain text:\n%s", messages[1])
+	}
+}
+
+func TestSMTPNotifier_StartTLSRequired(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	notifier := infrastructure.NewSMTPNotifier(infrastructure.SMTPOptions{
+		Host:        "127.0.0.1",
+		Port:        server.port(),
+		Security:    infrastructure.SMTPSecuritySTARTTLS,
+		DialTimeout: time.Second,
+	}, "Weather", "weather@example.com")
+
+	_, err := notifier.Send("user@example.com", "Subject", "Body", "")
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Fatalf("Send() error = %v, want STARTTLS error", err)
+	}
+	_, messages := server.snapshot()
+	if len(messages) != 0 {
+		t.Errorf("messages = %d, want 0", len(messages))
+	}
+}
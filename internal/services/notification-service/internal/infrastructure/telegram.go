@@ -0,0 +1,116 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"notification-service/internal/domain"
+)
+
+const telegramRequestTimeout = 10 * time.Second
+
+type telegramSendMessageRequest struct {
+	ChatID string `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+	Result      struct {
+		MessageID int64 `json:"message_id"`
+	} `json:"result"`
+}
+
+// TelegramNotifier delivers notifications as Bot API messages, with the chat
+// id as the recipient. It satisfies the same interface as SendgridNotifier.
+type TelegramNotifier struct {
+	client  httpClientManager
+	baseURL string
+	token   string
+}
+
+func NewTelegramNotifier(client httpClientManager, baseURL, token string) *TelegramNotifier {
+	return &TelegramNotifier{
+		client:  client,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+	}
+}
+
+func (t *TelegramNotifier) Provider() string {
+	return "telegram"
+}
+
+// Send posts the subject and plain-text body; Telegram has no use for the
+// HTML part of email templates.
+func (t *TelegramNotifier) Send(to, subject, textBody, htmlBody string) (domain.SendResult, error) {
+	text := strings.TrimSpace(textBody)
+	if subject != "" {
+		text = subject + "\n\n" + text
+	}
+	payload, err := json.Marshal(telegramSendMessageRequest{ChatID: to, Text: text})
+	if err != nil {
+		return domain.SendResult{}, fmt.Errorf("failed to marshal telegram message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), telegramRequestTimeout)
+	defer cancel()
+
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", t.baseURL, t.token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return domain.SendResult{}, fmt.Errorf("failed to build telegram request: %w", redactURL(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	log.Printf("[TelegramNotifier] Sending message to chat: %s, subject: %s\n", to, subject)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		err = redactURL(err)
+		log.Printf("[TelegramNotifier] Error sending message to chat %s: %v\n", to, err)
+		return domain.SendResult{}, fmt.Errorf("failed to send telegram message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result telegramResponse
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	_ = json.Unmarshal(body, &result)
+	if resp.StatusCode >= 300 || !result.OK {
+		log.Printf(
+			"[TelegramNotifier] Bot API returned status code %d for chat %s. Response body: %s",
+			resp.StatusCode, to, body,
+		)
+		return domain.SendResult{}, &domain.ProviderError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("telegram returned error status code %d: %s", resp.StatusCode, result.Description),
+		}
+	}
+
+	log.Printf("[TelegramNotifier] Message sent successfully to chat: %s\n", to)
+	return domain.SendResult{
+		MessageID:  strconv.FormatInt(result.Result.MessageID, 10),
+		StatusCode: resp.StatusCode,
+	}, nil
+}
+
+// redactURL drops the request URL from err, since Bot API URLs embed the bot
+// token and errors end up in logs and delivery history.
+func redactURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("%s request failed: %w", urlErr.Op, urlErr.Err)
+	}
+	return err
+}
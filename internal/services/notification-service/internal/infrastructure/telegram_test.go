@@ -0,0 +1,80 @@
+package infrastructure_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"notification-service/internal/infrastructure"
+)
+
+func newFakeBotAPI(t *testing.T, token string, status int, response string) (*httptest.Server, *[]map[string]string) {
+	t.Helper()
+	var received []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bot"+token+"/sendMessage" {
+			http.NotFound(w, r)
+			return
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		received = append(received, body)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, &received
+}
+
+func TestTelegramNotifier_Send(t *testing.T) {
+	server, received := newFakeBotAPI(t, "test-token", http.StatusOK, `{"ok":true,"result":{"message_id":42}}`)
+	notifier := infrastructure.NewTelegramNotifier(server.Client(), server.URL, "test-token")
+
+	result, err := notifier.Send("12345", "Weather update for Kyiv", "Sunny, 21.5°C\n", "<p>ignored</p>")
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if result.MessageID != "42" || result.StatusCode != http.StatusOK {
+		t.Errorf("result = %+v, want message id 42 and status 200", result)
+	}
+
+	if len(*received) != 1 {
+		t.Fatalf("requests = %d, want 1", len(*received))
+	}
+	got := (*received)[0]
+	if got["chat_id"] != "12345" {
+		t.Errorf("chat_id = %q, want %q", got["chat_id"], "12345")
+	}
+	if got["text"] != "Weather update for Kyiv\n\nSunny, 21.5°C" {
+		t.Errorf("text = %q", got["text"])
+	}
+}
+
+func TestTelegramNotifier_SendError(t *testing.T) {
+	server, _ := newFakeBotAPI(t, "test-token", http.StatusBadRequest,
+		`{"ok":false,"description":"Bad Request: chat not found"}`)
+	notifier := infrastructure.NewTelegramNotifier(server.Client(), server.URL, "test-token")
+
+	_, err := notifier.Send("1", "Subject", "Body", "")
+	if err == nil || !strings.Contains(err.Error(), "chat not found") {
+		t.Fatalf("Send() error = %v, want chat not found", err)
+	}
+}
+
+func TestTelegramNotifier_SendErrorDoesNotLeakToken(t *testing.T) {
+	server, _ := newFakeBotAPI(t, "secret-token", http.StatusOK, `{"ok":true}`)
+	server.Close()
+	notifier := infrastructure.NewTelegramNotifier(server.Client(), server.URL, "secret-token")
+
+	_, err := notifier.Send("1", "Subject", "Body", "")
+	if err == nil {
+		t.Fatal("Send() error = nil, want connection error")
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("Send() error = %q, leaks the bot token", err)
+	}
+}
@@ -0,0 +1,103 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"notification-service/internal/domain"
+)
+
+const twilioRequestTimeout = 10 * time.Second
+
+type httpClientManager interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type twilioMessageResponse struct {
+	SID string `json:"sid"`
+}
+
+// TwilioSMSSender sends SMS through the Twilio Messages API or any provider
+// exposing the same form-encoded endpoint.
+type TwilioSMSSender struct {
+	client     httpClientManager
+	baseURL    string
+	accountSID string
+	authToken  string
+	from       string
+}
+
+func NewTwilioSMSSender(
+	client httpClientManager,
+	baseURL string,
+	accountSID string,
+	authToken string,
+	from string,
+) *TwilioSMSSender {
+	return &TwilioSMSSender{
+		client:     client,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+	}
+}
+
+func (t *TwilioSMSSender) Provider() string {
+	return "twilio"
+}
+
+// SendSMS returns the message SID assigned by the provider.
+func (t *TwilioSMSSender) SendSMS(to, body string) (domain.SendResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), twilioRequestTimeout)
+	defer cancel()
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", t.baseURL, url.PathEscape(t.accountSID))
+	form := url.Values{
+		"To":   {to},
+		"From": {t.from},
+		"Body": {body},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return domain.SendResult{}, fmt.Errorf("failed to build sms request: %w", err)
+	}
+	req.SetBasicAuth(t.accountSID, t.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	log.Printf("[TwilioSMSSender] Sending SMS to: %s\n", to)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		log.Printf("[TwilioSMSSender] Error sending SMS to %s: %v\n", to, err)
+		return domain.SendResult{}, fmt.Errorf("failed to send sms: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		log.Printf(
+			"[TwilioSMSSender] Provider returned error status code %d for %s. Response body: %s",
+			resp.StatusCode, to, respBody,
+		)
+		return domain.SendResult{}, &domain.ProviderError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("sms provider returned error status code %d", resp.StatusCode),
+		}
+	}
+
+	var result twilioMessageResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&result); err != nil {
+		log.Printf("[TwilioSMSSender] Failed to decode response for %s: %v\n", to, err)
+	}
+
+	log.Printf("[TwilioSMSSender] SMS sent successfully to: %s\n", to)
+	return domain.SendResult{MessageID: result.SID, StatusCode: resp.StatusCode}, nil
+}
@@ -0,0 +1,98 @@
+package infrastructure_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/infrastructure"
+)
+
+type twilioRequest struct {
+	path     string
+	form     url.Values
+	user     string
+	password string
+}
+
+func newFakeTwilio(t *testing.T, status int, response string) (*httptest.Server, *[]twilioRequest) {
+	t.Helper()
+	var received []twilioRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parse form: %v", err)
+		}
+		user, password, _ := r.BasicAuth()
+		received = append(received, twilioRequest{path: r.URL.Path, form: r.PostForm, user: user, password: password})
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, &received
+}
+
+func TestTwilioSMSSender_SendSMS(t *testing.T) {
+	server, received := newFakeTwilio(t, http.StatusCreated, `{"sid":"SM123","status":"queued"}`)
+	sender := infrastructure.NewTwilioSMSSender(server.Client(), server.URL+"/", "AC42", "secret", "+15005550006")
+
+	result, err := sender.SendSMS("+380501234567", "Kyiv: Sunny, 21.5°C")
+	if err != nil {
+		t.Fatalf("SendSMS() error = %v", err)
+	}
+	if result.MessageID != "SM123" || result.StatusCode != http.StatusCreated {
+		t.Errorf("result = %+v, want SID SM123 and status 201", result)
+	}
+
+	if len(*received) != 1 {
+		t.Fatalf("requests = %d, want 1", len(*received))
+	}
+	got := (*received)[0]
+	if got.path != "/2010-04-01/Accounts/AC42/Messages.json" {
+		t.Errorf("path = %q", got.path)
+	}
+	if got.user != "AC42" || got.password != "secret" {
+		t.Errorf("basic auth = %q:%q, want the account SID and auth token", got.user, got.password)
+	}
+	want := map[string]string{"To": "+380501234567", "From": "+15005550006", "Body": "Kyiv: Sunny, 21.5°C"}
+	for field, value := range want {
+		if got.form.Get(field) != value {
+			t.Errorf("form %s = %q, want %q", field, got.form.Get(field), value)
+		}
+	}
+}
+
+func TestTwilioSMSSender_ProviderError(t *testing.T) {
+	server, _ := newFakeTwilio(t, http.StatusBadRequest, `{"code":21211,"message":"invalid 'To' number"}`)
+	sender := infrastructure.NewTwilioSMSSender(server.Client(), server.URL, "AC42", "secret", "+15005550006")
+
+	result, err := sender.SendSMS("+380501234567", "hello")
+
+	var providerErr *domain.ProviderError
+	if !errors.As(err, &providerErr) {
+		t.Fatalf("SendSMS() error = %v, want a ProviderError", err)
+	}
+	if providerErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("status code = %d, want 400", providerErr.StatusCode)
+	}
+	if result.MessageID != "" {
+		t.Errorf("message id = %q, want none", result.MessageID)
+	}
+}
+
+func TestFakeSMSSender_RecordsMessages(t *testing.T) {
+	sender := infrastructure.NewFakeSMSSender()
+
+	first, _ := sender.SendSMS("+380501234567", "one")
+	second, _ := sender.SendSMS("+380671234567", "two")
+
+	if first.MessageID == second.MessageID {
+		t.Errorf("message ids = %q and %q, want distinct ids", first.MessageID, second.MessageID)
+	}
+	sent := sender.Sent()
+	if len(sent) != 2 || sent[0].To != "+380501234567" || sent[1].Body != "two" {
+		t.Errorf("sent = %+v", sent)
+	}
+}
@@ -0,0 +1,153 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"notification-service/internal/domain"
+)
+
+const (
+	WebhookSignatureHeader = "X-Weather-Signature"
+	WebhookTimestampHeader = "X-Weather-Timestamp"
+	WebhookEventHeader     = "X-Weather-Event"
+
+	webhookStoreTimeout = 3 * time.Second
+)
+
+// ErrWebhookDisabled is returned for deliveries to an endpoint that was
+// disabled after repeated failures.
+var ErrWebhookDisabled = errors.New("webhook endpoint is disabled")
+
+type webhookStoreManager interface {
+	IsDisabled(ctx context.Context, url string) (bool, error)
+	RecordSuccess(ctx context.Context, url string) error
+	RecordFailure(ctx context.Context, url, reason string, disableAfter int) (bool, error)
+}
+
+type WebhookPayload struct {
+	Event  string `json:"event"`
+	Data   any    `json:"data"`
+	SentAt int64  `json:"sent_at"`
+}
+
+type WebhookOptions struct {
+	Secret       string
+	Timeout      time.Duration
+	DisableAfter int
+}
+
+// WebhookNotifier POSTs JSON payloads signed with HMAC-SHA256. Each call makes
+// a single attempt; failed deliveries are returned so that the Kafka consumer
+// retries them with backoff and dead-letters them in the end. An endpoint
+// failing DisableAfter attempts in a row is disabled in the store until an
+// operator re-enables it.
+type WebhookNotifier struct {
+	client httpClientManager
+	store  webhookStoreManager
+	opts   WebhookOptions
+}
+
+func NewWebhookNotifier(client httpClientManager, store webhookStoreManager, opts WebhookOptions) *WebhookNotifier {
+	return &WebhookNotifier{
+		client: client,
+		store:  store,
+		opts:   opts,
+	}
+}
+
+func (w *WebhookNotifier) Provider() string {
+	return "webhook"
+}
+
+// Deliver sends event to url and returns the status code the endpoint answered
+// with. Deliveries to a disabled endpoint fail with a domain.PermanentError
+// wrapping ErrWebhookDisabled.
+func (w *WebhookNotifier) Deliver(url, event string, data any) (domain.SendResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookStoreTimeout)
+	defer cancel()
+	disabled, err := w.store.IsDisabled(ctx, url)
+	if err != nil {
+		return domain.SendResult{}, fmt.Errorf("failed to check webhook endpoint state: %w", err)
+	}
+	if disabled {
+		log.Printf("[WebhookNotifier] Skipping disabled endpoint %s for event %s", url, event)
+		return domain.SendResult{}, &domain.PermanentError{Err: fmt.Errorf("%w: %s", ErrWebhookDisabled, url)}
+	}
+
+	body, err := json.Marshal(WebhookPayload{Event: event, Data: data, SentAt: time.Now().Unix()})
+	if err != nil {
+		return domain.SendResult{}, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	statusCode, sendErr := w.post(url, event, body)
+	recordCtx, cancelRecord := context.WithTimeout(context.Background(), webhookStoreTimeout)
+	defer cancelRecord()
+	if sendErr == nil {
+		log.Printf("[WebhookNotifier] Event %s delivered to %s", event, url)
+		if err := w.store.RecordSuccess(recordCtx, url); err != nil {
+			log.Printf("[WebhookNotifier] Failed to reset failures of %s: %v", url, err)
+		}
+		return domain.SendResult{StatusCode: statusCode}, nil
+	}
+
+	log.Printf("[WebhookNotifier] Delivery of %s to %s failed: %v", event, url, sendErr)
+	disabled, err = w.store.RecordFailure(recordCtx, url, sendErr.Error(), w.opts.DisableAfter)
+	if err != nil {
+		log.Printf("[WebhookNotifier] Failed to record failure of %s: %v", url, err)
+	} else if disabled {
+		log.Printf("[WebhookNotifier] Disabled %s after %d consecutive failed deliveries", url, w.opts.DisableAfter)
+	}
+	return domain.SendResult{}, fmt.Errorf("failed to deliver webhook to %s: %w", url, sendErr)
+}
+
+func (w *WebhookNotifier) post(url, event string, body []byte) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), w.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhook(w.opts.Secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, &domain.ProviderError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("endpoint returned status code %d", resp.StatusCode),
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhook returns the hex HMAC-SHA256 of "<timestamp>.<body>", which
+// receivers recompute to verify X-Weather-Signature.
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
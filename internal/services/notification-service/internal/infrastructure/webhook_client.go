@@ -0,0 +1,57 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenWebhookAddress is returned when a webhook URL resolves to an
+// address that webhooks may not reach.
+var ErrForbiddenWebhookAddress = errors.New("webhook address is not allowed")
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which some
+// clouds use for internal services such as instance metadata.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// NewWebhookHTTPClient returns the client used for webhook deliveries. It
+// refuses to connect to loopback, private, link-local (which includes cloud
+// metadata endpoints) and other non-public addresses unless they fall within
+// one of allowed. The check runs on the resolved address of every connection,
+// redirects included, so host names pointing at internal hosts are rejected
+// as well.
+func NewWebhookHTTPClient(timeout time.Duration, allowed []netip.Prefix) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			return checkWebhookAddress(address, allowed)
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would make the dialer see the proxy's address instead of the
+	// endpoint's.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Transport: transport}
+}
+
+func checkWebhookAddress(address string, allowed []netip.Prefix) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenWebhookAddress, address)
+	}
+	addr := addrPort.Addr().Unmap()
+	for _, prefix := range allowed {
+		if prefix.Contains(addr) {
+			return nil
+		}
+	}
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || sharedAddressSpace.Contains(addr) {
+		return fmt.Errorf("%w: %s", ErrForbiddenWebhookAddress, addr)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package infrastructure_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"notification-service/internal/infrastructure"
+)
+
+func TestWebhookHTTPClient_RejectsLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached a loopback endpoint")
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewWebhookHTTPClient(time.Second, nil)
+	_, err := client.Get(server.URL)
+	if !errors.Is(err, infrastructure.ErrForbiddenWebhookAddress) {
+		t.Fatalf("Get() error = %v, want %v", err, infrastructure.ErrForbiddenWebhookAddress)
+	}
+}
+
+func TestWebhookHTTPClient_RejectsInternalAddresses(t *testing.T) {
+	client := infrastructure.NewWebhookHTTPClient(time.Second, nil)
+	for _, url := range []string{
+		"http://169.254.169.254/latest/meta-data/",
+		"http://10.0.0.1/",
+		"http://192.168.1.1/",
+		"http://100.100.100.200/",
+		"http://[::1]/",
+		"http://[fd00::1]/",
+		"http://0.0.0.0/",
+	} {
+		t.Run(url, func(t *testing.T) {
+			_, err := client.Get(url)
+			if !errors.Is(err, infrastructure.ErrForbiddenWebhookAddress) {
+				t.Fatalf("Get() error = %v, want %v", err, infrastructure.ErrForbiddenWebhookAddress)
+			}
+		})
+	}
+}
+
+func TestWebhookHTTPClient_AllowsListedNetworks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := infrastructure.NewWebhookHTTPClient(time.Second, []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")})
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+}
@@ -0,0 +1,135 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/infrastructure"
+)
+
+// fakeWebhookStore mirrors the consecutive failure counting of the Postgres
+// webhook endpoint repository.
+type fakeWebhookStore struct {
+	failures map[string]int
+	disabled map[string]bool
+}
+
+func newFakeWebhookStore() *fakeWebhookStore {
+	return &fakeWebhookStore{failures: map[string]int{}, disabled: map[string]bool{}}
+}
+
+func (s *fakeWebhookStore) IsDisabled(_ context.Context, url string) (bool, error) {
+	return s.disabled[url], nil
+}
+
+func (s *fakeWebhookStore) RecordSuccess(_ context.Context, url string) error {
+	s.failures[url] = 0
+	return nil
+}
+
+func (s *fakeWebhookStore) RecordFailure(_ context.Context, url, _ string, disableAfter int) (bool, error) {
+	s.failures[url]++
+	if disableAfter > 0 && s.failures[url] >= disableAfter {
+		s.disabled[url] = true
+	}
+	return s.disabled[url], nil
+}
+
+func newWebhookNotifier(store *fakeWebhookStore) *infrastructure.WebhookNotifier {
+	return infrastructure.NewWebhookNotifier(http.DefaultClient, store, infrastructure.WebhookOptions{
+		Secret:       "secret",
+		Timeout:      time.Second,
+		DisableAfter: 2,
+	})
+}
+
+func TestWebhookNotifier_DeliverSignsPayload(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		want := "sha256=" + infrastructure.SignWebhook("secret", r.Header.Get(infrastructure.WebhookTimestampHeader), body)
+		if got := r.Header.Get(infrastructure.WebhookSignatureHeader); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+		if got := r.Header.Get(infrastructure.WebhookEventHeader); got != domain.WeatherUpdateTemplate {
+			t.Errorf("event = %q, want %q", got, domain.WeatherUpdateTemplate)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	store := newFakeWebhookStore()
+	result, err := newWebhookNotifier(store).Deliver(server.URL, domain.WeatherUpdateTemplate, domain.WeatherMetrics{City: "Kyiv"})
+	if err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if result.StatusCode != http.StatusNoContent {
+		t.Errorf("status code = %d, want %d", result.StatusCode, http.StatusNoContent)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestWebhookNotifier_FailuresAreReturnedAndDisable(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	store := newFakeWebhookStore()
+	notifier := newWebhookNotifier(store)
+
+	for i := 0; i < 2; i++ {
+		_, err := notifier.Deliver(server.URL, domain.WeatherUpdateTemplate, nil)
+		var providerErr *domain.ProviderError
+		if !errors.As(err, &providerErr) || providerErr.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("Deliver() error = %v, want provider error with status 500", err)
+		}
+	}
+	if !store.disabled[server.URL] {
+		t.Fatal("endpoint should be disabled after 2 failures")
+	}
+
+	_, err := notifier.Deliver(server.URL, domain.WeatherUpdateTemplate, nil)
+	var permanent *domain.PermanentError
+	if !errors.Is(err, infrastructure.ErrWebhookDisabled) || !errors.As(err, &permanent) {
+		t.Fatalf("Deliver() error = %v, want permanent %v", err, infrastructure.ErrWebhookDisabled)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("calls = %d, want 2 (a disabled endpoint is not called)", calls.Load())
+	}
+}
+
+func TestWebhookNotifier_SuccessResetsFailures(t *testing.T) {
+	var fail atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newFakeWebhookStore()
+	notifier := newWebhookNotifier(store)
+
+	for _, failing := range []bool{true, false, true} {
+		fail.Store(failing)
+		_, _ = notifier.Deliver(server.URL, domain.WeatherUpdateTemplate, nil)
+	}
+	if store.disabled[server.URL] {
+		t.Error("endpoint should stay enabled: its failures were not consecutive")
+	}
+}
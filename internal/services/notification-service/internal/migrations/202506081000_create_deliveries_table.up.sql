@@ -0,0 +1,18 @@
+CREATE TABLE deliveries (
+	id BIGSERIAL PRIMARY KEY,
+	event_id VARCHAR(64),
+	channel_type VARCHAR(20) NOT NULL,
+	recipient_hash CHAR(64) NOT NULL,
+	template VARCHAR(100) NOT NULL,
+	provider VARCHAR(50),
+	provider_message_id TEXT,
+	status VARCHAR(20) NOT NULL CHECK (status IN ('sent', 'failed')),
+	attempts INT NOT NULL DEFAULT 1,
+	error TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	sent_at TIMESTAMP,
+	UNIQUE (event_id, channel_type, recipient_hash, template)
+);
+
+CREATE INDEX idx_deliveries_recipient_created_at ON deliveries (recipient_hash, created_at);
@@ -0,0 +1,13 @@
+CREATE TABLE email_events (
+	id BIGSERIAL PRIMARY KEY,
+	provider_event_id VARCHAR(100) NOT NULL UNIQUE,
+	provider_message_id TEXT,
+	recipient_hash CHAR(64) NOT NULL,
+	event VARCHAR(20) NOT NULL,
+	bounce_type VARCHAR(20),
+	reason TEXT,
+	occurred_at TIMESTAMP NOT NULL,
+	received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_email_events_recipient_hash ON email_events (recipient_hash);
@@ -0,0 +1,11 @@
+CREATE TABLE suppressions (
+	recipient_hash CHAR(64) PRIMARY KEY,
+	channel_type VARCHAR(20) NOT NULL,
+	reason TEXT NOT NULL,
+	source VARCHAR(32) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expires_at TIMESTAMP
+);
+
+ALTER TABLE deliveries DROP CONSTRAINT deliveries_status_check;
+ALTER TABLE deliveries ADD CONSTRAINT deliveries_status_check CHECK (status IN ('sent', 'failed', 'skipped'));
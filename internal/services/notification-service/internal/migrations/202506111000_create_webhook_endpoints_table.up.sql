@@ -0,0 +1,7 @@
+CREATE TABLE webhook_endpoints (
+	url TEXT PRIMARY KEY,
+	consecutive_failures INT NOT NULL DEFAULT 0,
+	last_error TEXT,
+	disabled_at TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
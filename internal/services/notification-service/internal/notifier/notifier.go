@@ -1,53 +1,132 @@
 package notifier
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"strings"
+	"time"
 
 	"notification-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EmailChannel    = "email"
+	SMSChannel      = "sms"
+	TelegramChannel = "telegram"
+	WebhookChannel  = "webhook"
 )
 
 const (
-	ConfirmTemplate = "confirm"
-	WeatherUpdateTemplate = "weather_update"
-	UnsubscribeTemplate = "unsubscribe"
+	deliveryLogTimeout       = 3 * time.Second
+	suppressionLookupTimeout = 3 * time.Second
+
+	manageLinkExpiryLayout = "2006-01-02 15:04 UTC"
 )
 
+var ErrChannelNotConfigured = errors.New("channel is not configured")
+
+// Senders return the message ID and status code of the provider, if any.
 type emailNotifierManager interface {
-	Send(to, message, subject string) error
+	Send(to, subject, textBody, htmlBody string) (domain.SendResult, error)
+}
+
+type smsSenderManager interface {
+	SendSMS(to, body string) (domain.SendResult, error)
+}
+
+type webhookSenderManager interface {
+	Deliver(url, event string, data any) (domain.SendResult, error)
+}
+
+type templateRendererManager interface {
+	Render(name string, data any) (domain.RenderedMessage, error)
 }
 
-type templateRepositoryManager interface {
-	GetTemplateByName(name string) (*domain.MessageTemplate, error)
+type auditPublisherManager interface {
+	PublishNotification(event domain.NotificationSentEvent) error
+}
+
+type deliveryLogManager interface {
+	Record(ctx context.Context, d domain.Delivery) error
+}
+
+type suppressionListManager interface {
+	Lookup(ctx context.Context, recipientHash string) (*domain.Suppression, error)
+}
+
+// providerManager is implemented by senders that can name their backend.
+type providerManager interface {
+	Provider() string
 }
 
 type Service struct {
 	notifier  emailNotifierManager
-	templates templateRepositoryManager
+	sms       smsSenderManager
+	telegram  emailNotifierManager
+	webhook   webhookSenderManager
+	templates  templateRendererManager
+	audit      auditPublisherManager
+	deliveries deliveryLogManager
+	suppressed suppressionListManager
+	eventID    string
 }
 
+// NewService builds the notification dispatcher. sms, telegram and webhook may
+// be nil, in which case notifications on that channel fail with
+// ErrChannelNotConfigured. audit, deliveries and suppressed may be nil to
+// disable audit events, the delivery log and the suppression check.
 func NewService(
 	notifier emailNotifierManager,
-	templates templateRepositoryManager,
+	sms smsSenderManager,
+	telegram emailNotifierManager,
+	webhook webhookSenderManager,
+	templates templateRendererManager,
+	audit auditPublisherManager,
+	deliveries deliveryLogManager,
+	suppressed suppressionListManager,
 ) *Service {
 	return &Service{
-		notifier:  notifier,
-		templates: templates,
+		notifier:   notifier,
+		sms:        sms,
+		telegram:   telegram,
+		webhook:    webhook,
+		templates:  templates,
+		audit:      audit,
+		deliveries: deliveries,
+		suppressed: suppressed,
 	}
 }
 
+// ForEvent returns a Service whose sends are logged against the source event,
+// so redelivered events count as further attempts of the same delivery.
+func (s *Service) ForEvent(eventID string) *Service {
+	scoped := *s
+	scoped.eventID = eventID
+	return &scoped
+}
+
 func (s *Service) SendConfirmation(
 	channel string,
 	recipient string,
 	token string,
 ) error {
-	tpl, err := s.templates.GetTemplateByName(ConfirmTemplate)
-	if err != nil {
-		return fmt.Errorf("failed to load template: %v", err)
-	}
-	message := strings.ReplaceAll(tpl.Message, "{{ .ConfirmToken }}", token)
-	subject := tpl.Subject
-	return s.notifier.Send(recipient, message, subject)
+	return s.send(channel, recipient, domain.ConfirmTemplate, domain.ConfirmationData{ConfirmToken: token})
+}
+
+func (s *Service) SendWelcome(
+	channel string,
+	recipient string,
+	city string,
+	unsubscribeToken string,
+) error {
+	return s.send(channel, recipient, domain.WelcomeTemplate, domain.WelcomeData{
+		City:             city,
+		UnsubscribeToken: unsubscribeToken,
+	})
 }
 
 func (s *Service) SendWeatherUpdate(
@@ -55,29 +134,7 @@ func (s *Service) SendWeatherUpdate(
 	recipient string,
 	metrics domain.WeatherMetrics,
 ) error {
-	tpl, err := s.templates.GetTemplateByName(WeatherUpdateTemplate)
-	if err != nil {
-		return fmt.Errorf("failed to load template: %v", err)
-	}
-	
-	replacements := map[string]string{
-		"{{ .City }}":        metrics.City,
-		"{{ .Description }}": metrics.Description,
-		"{{ .Temperature }}": fmt.Sprintf("%.1f", metrics.Temperature),
-		"{{ .Humidity }}":    fmt.Sprintf("%.1f", metrics.Humidity),
-	}
-			
-	message := tpl.Message
-	subject := tpl.Subject
-	
-	for placeholder, value := range replacements {
-		message = strings.ReplaceAll(message, placeholder, value)
-		if placeholder == "{{ .City }}" {
-			subject = strings.ReplaceAll(subject, placeholder, value)
-		}
-	}
-	
-	return s.notifier.Send(recipient, message, subject)
+	return s.send(channel, recipient, domain.WeatherUpdateTemplate, metrics)
 }
 
 func (s *Service) SendUnsubscribe(
@@ -85,12 +142,199 @@ func (s *Service) SendUnsubscribe(
 	recipient string,
 	city string,
 ) error {
-	tpl, err := s.templates.GetTemplateByName(UnsubscribeTemplate)
+	return s.send(channel, recipient, domain.UnsubscribeTemplate, domain.UnsubscribeData{City: city})
+}
+
+func (s *Service) SendSubscriptionUpdated(
+	channel string,
+	recipient string,
+	city string,
+	schedule string,
+) error {
+	return s.send(channel, recipient, domain.SubscriptionUpdatedTemplate, domain.SubscriptionChangeData{
+		City:     city,
+		Schedule: schedule,
+	})
+}
+
+func (s *Service) SendSubscriptionPaused(
+	channel string,
+	recipient string,
+	city string,
+) error {
+	return s.send(channel, recipient, domain.SubscriptionPausedTemplate, domain.SubscriptionChangeData{City: city})
+}
+
+func (s *Service) SendSubscriptionResumed(
+	channel string,
+	recipient string,
+	city string,
+	schedule string,
+) error {
+	return s.send(channel, recipient, domain.SubscriptionResumedTemplate, domain.SubscriptionChangeData{
+		City:     city,
+		Schedule: schedule,
+	})
+}
+
+// SendManageLink mails the signed manage-portal link; expiresAt is shown in UTC.
+func (s *Service) SendManageLink(
+	channel string,
+	recipient string,
+	link string,
+	expiresAt time.Time,
+) error {
+	return s.send(channel, recipient, domain.ManageLinkTemplate, domain.ManageLinkData{
+		Link:      link,
+		ExpiresAt: expiresAt.UTC().Format(manageLinkExpiryLayout),
+	})
+}
+
+// send delivers one notification and publishes its outcome for auditing.
+// Sends to suppressed recipients are logged as skipped and succeed, so the
+// caller does not retry them.
+func (s *Service) send(channel, recipient, templateName string, data any) error {
+	suppression, err := s.suppressionOf(recipient)
 	if err != nil {
-		return fmt.Errorf("failed to load template: %v", err)
+		return err
+	}
+	if suppression != nil {
+		log.Printf("[NotificationService] Skipping %s %s notification to suppressed recipient (%s via %s)",
+			channel, templateName, suppression.Reason, suppression.Source)
+		s.recordDelivery(channel, recipient, templateName, "",
+			domain.DeliveryStatusSkipped, "recipient suppressed: "+suppression.Reason)
+		return nil
 	}
-	message := strings.ReplaceAll(tpl.Message, "{{ .City }}", city)
-	subject := strings.ReplaceAll(tpl.Subject, "{{ .City }}", city)
 
-	return s.notifier.Send(recipient, message, subject)
+	started := time.Now()
+	result, err := s.dispatch(channel, recipient, templateName, data)
+	s.publishAudit(channel, recipient, templateName, started, result, err)
+	if err != nil {
+		s.recordDelivery(channel, recipient, templateName, result.MessageID, domain.DeliveryStatusFailed, err.Error())
+	} else {
+		s.recordDelivery(channel, recipient, templateName, result.MessageID, domain.DeliveryStatusSent, "")
+	}
+	return err
+}
+
+func (s *Service) suppressionOf(recipient string) (*domain.Suppression, error) {
+	if s.suppressed == nil {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), suppressionLookupTimeout)
+	defer cancel()
+	suppression, err := s.suppressed.Lookup(ctx, domain.HashRecipient(recipient))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check suppression list: %w", err)
+	}
+	return suppression, nil
+}
+
+func (s *Service) dispatch(channel, recipient, templateName string, data any) (domain.SendResult, error) {
+	switch channel {
+	case EmailChannel:
+		msg, err := s.templates.Render(templateName, data)
+		if err != nil {
+			return domain.SendResult{}, fmt.Errorf("failed to render template: %w", err)
+		}
+		return s.notifier.Send(recipient, msg.Subject, msg.Text, msg.HTML)
+	case SMSChannel:
+		if s.sms == nil {
+			return domain.SendResult{}, fmt.Errorf("%s: %w", channel, ErrChannelNotConfigured)
+		}
+		msg, err := s.templates.Render(domain.SMSTemplate(templateName), data)
+		if err != nil {
+			return domain.SendResult{}, fmt.Errorf("failed to render template: %w", err)
+		}
+		return s.sms.SendSMS(recipient, strings.TrimSpace(msg.Text))
+	case TelegramChannel:
+		if s.telegram == nil {
+			return domain.SendResult{}, fmt.Errorf("%s: %w", channel, ErrChannelNotConfigured)
+		}
+		msg, err := s.templates.Render(templateName, data)
+		if err != nil {
+			return domain.SendResult{}, fmt.Errorf("failed to render template: %w", err)
+		}
+		return s.telegram.Send(recipient, msg.Subject, msg.Text, msg.HTML)
+	case WebhookChannel:
+		if s.webhook == nil {
+			return domain.SendResult{}, fmt.Errorf("%s: %w", channel, ErrChannelNotConfigured)
+		}
+		return s.webhook.Deliver(recipient, templateName, data)
+	default:
+		return domain.SendResult{}, fmt.Errorf("unsupported channel: %s", channel)
+	}
+}
+
+func (s *Service) publishAudit(
+	channel, recipient, templateName string,
+	started time.Time,
+	result domain.SendResult,
+	sendErr error,
+) {
+	if s.audit == nil {
+		return
+	}
+	event := domain.NotificationSentEvent{
+		NotificationID: uuid.NewString(),
+		EventType:      domain.NotificationSentEventType,
+		ChannelType:    channel,
+		RecipientHash:  domain.HashRecipient(recipient),
+		Template:       templateName,
+		Provider:       s.providerOf(channel),
+		ResponseCode:   result.StatusCode,
+		LatencyMs:      time.Since(started).Milliseconds(),
+		SentAt:         time.Now().Unix(),
+	}
+	if sendErr != nil {
+		event.EventType = domain.NotificationFailedEventType
+		event.Error = sendErr.Error()
+		var providerErr *domain.ProviderError
+		if errors.As(sendErr, &providerErr) {
+			event.ResponseCode = providerErr.StatusCode
+		}
+	}
+	if err := s.audit.PublishNotification(event); err != nil {
+		log.Printf("[NotificationService] Failed to publish %s audit event: %v", event.EventType, err)
+	}
+}
+
+func (s *Service) providerOf(channel string) string {
+	var sender any
+	switch channel {
+	case EmailChannel:
+		sender = s.notifier
+	case SMSChannel:
+		sender = s.sms
+	case TelegramChannel:
+		sender = s.telegram
+	case WebhookChannel:
+		sender = s.webhook
+	}
+	if p, ok := sender.(providerManager); ok {
+		return p.Provider()
+	}
+	return ""
+}
+
+func (s *Service) recordDelivery(channel, recipient, templateName, messageID, status, detail string) {
+	if s.deliveries == nil {
+		return
+	}
+	d := domain.Delivery{
+		EventID:           s.eventID,
+		ChannelType:       channel,
+		RecipientHash:     domain.HashRecipient(recipient),
+		Template:          templateName,
+		Provider:          s.providerOf(channel),
+		ProviderMessageID: messageID,
+		Status:            status,
+		Error:             detail,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryLogTimeout)
+	defer cancel()
+	if err := s.deliveries.Record(ctx, d); err != nil {
+		log.Printf("[NotificationService] Failed to record delivery: %v", err)
+	}
 }
@@ -0,0 +1,204 @@
+package notifier_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/notifier"
+)
+
+type stubWebhookSender struct {
+	result domain.SendResult
+	err    error
+}
+
+func (s *stubWebhookSender) Deliver(string, string, any) (domain.SendResult, error) {
+	return s.result, s.err
+}
+
+func (s *stubWebhookSender) Provider() string {
+	return "webhook"
+}
+
+type recordingDeliveryLog struct {
+	deliveries []domain.Delivery
+}
+
+func (l *recordingDeliveryLog) Record(_ context.Context, d domain.Delivery) error {
+	l.deliveries = append(l.deliveries, d)
+	return nil
+}
+
+type recordingAuditPublisher struct {
+	events []domain.NotificationSentEvent
+}
+
+func (p *recordingAuditPublisher) PublishNotification(event domain.NotificationSentEvent) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestService_AuditsWebhookOutcome(t *testing.T) {
+	tests := []struct {
+		name     string
+		sender   *stubWebhookSender
+		wantType string
+		wantCode int
+	}{
+		{
+			name:     "delivered",
+			sender:   &stubWebhookSender{result: domain.SendResult{StatusCode: http.StatusNoContent}},
+			wantType: domain.NotificationSentEventType,
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name: "endpoint error",
+			sender: &stubWebhookSender{err: fmt.Errorf("failed to deliver webhook: %w", &domain.ProviderError{
+				StatusCode: http.StatusBadGateway,
+				Message:    "endpoint returned status code 502",
+			})},
+			wantType: domain.NotificationFailedEventType,
+			wantCode: http.StatusBadGateway,
+		},
+		{
+			name:     "endpoint disabled",
+			sender:   &stubWebhookSender{err: &domain.PermanentError{Err: errors.New("webhook endpoint disabled")}},
+			wantType: domain.NotificationFailedEventType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := &recordingAuditPublisher{}
+			service := notifier.NewService(nil, nil, nil, tt.sender, nil, audit, nil, nil)
+
+			err := service.SendWeatherUpdate(notifier.WebhookChannel, "https://hooks.example.com/a", domain.WeatherMetrics{})
+			if (err != nil) != (tt.sender.err != nil) {
+				t.Fatalf("SendWeatherUpdate() error = %v", err)
+			}
+
+			if len(audit.events) != 1 {
+				t.Fatalf("audit events = %d, want 1", len(audit.events))
+			}
+			event := audit.events[0]
+			if event.EventType != tt.wantType {
+				t.Errorf("event type = %q, want %q", event.EventType, tt.wantType)
+			}
+			if event.ResponseCode != tt.wantCode {
+				t.Errorf("response code = %d, want %d", event.ResponseCode, tt.wantCode)
+			}
+			if event.Provider != "webhook" {
+				t.Errorf("provider = %q, want webhook", event.Provider)
+			}
+		})
+	}
+}
+
+func TestService_RecordsFailedWebhookDelivery(t *testing.T) {
+	deliveries := &recordingDeliveryLog{}
+	sender := &stubWebhookSender{err: &domain.ProviderError{
+		StatusCode: http.StatusInternalServerError,
+		Message:    "endpoint returned status code 500",
+	}}
+	service := notifier.NewService(nil, nil, nil, sender, nil, nil, deliveries, nil).ForEvent("event-1")
+
+	if err := service.SendWeatherUpdate(notifier.WebhookChannel, "https://hooks.example.com/a", domain.WeatherMetrics{}); err == nil {
+		t.Fatal("SendWeatherUpdate() error = nil, want the delivery failure")
+	}
+
+	if len(deliveries.deliveries) != 1 {
+		t.Fatalf("deliveries = %d, want 1", len(deliveries.deliveries))
+	}
+	d := deliveries.deliveries[0]
+	if d.Status != domain.DeliveryStatusFailed {
+		t.Errorf("status = %q, want %q", d.Status, domain.DeliveryStatusFailed)
+	}
+	if d.Error != "endpoint returned status code 500" || d.EventID != "event-1" {
+		t.Errorf("delivery = %+v, want the endpoint error against event-1", d)
+	}
+}
+
+type recordingRenderer struct {
+	rendered []string
+}
+
+func (r *recordingRenderer) Render(name string, _ any) (domain.RenderedMessage, error) {
+	r.rendered = append(r.rendered, name)
+	return domain.RenderedMessage{Subject: name, Text: "\n  Kyiv: Sunny, 21.5°C\n"}, nil
+}
+
+type recordingSMSSender struct {
+	to, body string
+}
+
+func (s *recordingSMSSender) SendSMS(to, body string) (domain.SendResult, error) {
+	s.to, s.body = to, body
+	return domain.SendResult{MessageID: "SM123"}, nil
+}
+
+func TestService_SendsSMSTemplate(t *testing.T) {
+	renderer := &recordingRenderer{}
+	sms := &recordingSMSSender{}
+	service := notifier.NewService(nil, sms, nil, nil, renderer, nil, nil, nil)
+
+	if err := service.SendWeatherUpdate(notifier.SMSChannel, "+380501234567", domain.WeatherMetrics{City: "Kyiv"}); err != nil {
+		t.Fatalf("SendWeatherUpdate() error = %v", err)
+	}
+
+	if len(renderer.rendered) != 1 || renderer.rendered[0] != domain.WeatherUpdateTemplate+".sms" {
+		t.Errorf("rendered %v, want [%s.sms]", renderer.rendered, domain.WeatherUpdateTemplate)
+	}
+	if sms.to != "+380501234567" || sms.body != "Kyiv: Sunny, 21.5°C" {
+		t.Errorf("sms = %q to %q, want the trimmed text to the recipient", sms.body, sms.to)
+	}
+}
+
+func TestService_SMSWithoutSender(t *testing.T) {
+	renderer := &recordingRenderer{}
+	service := notifier.NewService(nil, nil, nil, nil, renderer, nil, nil, nil)
+
+	err := service.SendWeatherUpdate(notifier.SMSChannel, "+380501234567", domain.WeatherMetrics{})
+	if !errors.Is(err, notifier.ErrChannelNotConfigured) {
+		t.Fatalf("SendWeatherUpdate() error = %v, want ErrChannelNotConfigured", err)
+	}
+	if len(renderer.rendered) != 0 {
+		t.Errorf("rendered %v, want nothing", renderer.rendered)
+	}
+}
+
+type stubSuppressionList struct {
+	suppression *domain.Suppression
+	hashes      []string
+}
+
+func (l *stubSuppressionList) Lookup(_ context.Context, recipientHash string) (*domain.Suppression, error) {
+	l.hashes = append(l.hashes, recipientHash)
+	return l.suppression, nil
+}
+
+func TestService_SkipsSuppressedRecipient(t *testing.T) {
+	sms := &recordingSMSSender{}
+	deliveries := &recordingDeliveryLog{}
+	suppressed := &stubSuppressionList{suppression: &domain.Suppression{Reason: "stop", Source: "twilio"}}
+	service := notifier.NewService(nil, sms, nil, nil, &recordingRenderer{}, nil, deliveries, suppressed)
+
+	if err := service.SendWeatherUpdate(notifier.SMSChannel, "+380501234567", domain.WeatherMetrics{}); err != nil {
+		t.Fatalf("SendWeatherUpdate() error = %v, want nil so the send is not retried", err)
+	}
+
+	if len(suppressed.hashes) != 1 || suppressed.hashes[0] != domain.HashRecipient("+380501234567") {
+		t.Errorf("looked up %v, want the recipient hash", suppressed.hashes)
+	}
+	if sms.to != "" {
+		t.Errorf("sms sent to %q, want no dispatch", sms.to)
+	}
+	if len(deliveries.deliveries) != 1 {
+		t.Fatalf("deliveries = %d, want 1", len(deliveries.deliveries))
+	}
+	if d := deliveries.deliveries[0]; d.Status != domain.DeliveryStatusSkipped || d.Error != "recipient suppressed: stop" {
+		t.Errorf("delivery = %+v, want a skipped record with the suppression reason", d)
+	}
+}
@@ -0,0 +1,113 @@
+package deliveries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"notification-service/internal/domain"
+)
+
+type databaseManager interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Query filters the delivery log. Zero From/To leave that bound open.
+type Query struct {
+	RecipientHash string
+	From          time.Time
+	To            time.Time
+	Limit         int
+}
+
+type Repository struct {
+	db databaseManager
+}
+
+func New(db databaseManager) *Repository {
+	return &Repository{db: db}
+}
+
+// Record stores a send attempt. Attempts for the same event, channel,
+// recipient and template update one row and bump its attempt counter;
+// deliveries without an event ID always get a new row.
+func (r *Repository) Record(ctx context.Context, d domain.Delivery) error {
+	now := time.Now().UTC()
+	var sentAt *time.Time
+	if d.Status == domain.DeliveryStatusSent {
+		sentAt = &now
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO deliveries (
+			event_id, channel_type, recipient_hash, template, provider,
+			provider_message_id, status, error, created_at, updated_at, sent_at
+		)
+		VALUES (NULLIF($1, ''), $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7, NULLIF($8, ''), $9, $9, $10)
+		ON CONFLICT (event_id, channel_type, recipient_hash, template) DO UPDATE
+		SET attempts = deliveries.attempts + 1,
+			provider = EXCLUDED.provider,
+			provider_message_id = EXCLUDED.provider_message_id,
+			status = EXCLUDED.status,
+			error = EXCLUDED.error,
+			updated_at = EXCLUDED.updated_at,
+			sent_at = EXCLUDED.sent_at`,
+		d.EventID, d.ChannelType, d.RecipientHash, d.Template, d.Provider,
+		d.ProviderMessageID, d.Status, d.Error, now, sentAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record delivery: %w", err)
+	}
+	return nil
+}
+
+// List returns the deliveries matching q, newest first.
+func (r *Repository) List(ctx context.Context, q Query) ([]domain.Delivery, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, COALESCE(event_id, ''), channel_type, recipient_hash, template,
+			COALESCE(provider, ''), COALESCE(provider_message_id, ''), status, attempts,
+			COALESCE(error, ''), created_at, updated_at, sent_at
+		FROM deliveries
+		WHERE recipient_hash = $1
+			AND ($2::timestamp IS NULL OR created_at >= $2)
+			AND ($3::timestamp IS NULL OR created_at < $3)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4`,
+		q.RecipientHash, nullTime(q.From), nullTime(q.To), q.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.Delivery
+	for rows.Next() {
+		var (
+			d      domain.Delivery
+			sentAt sql.NullTime
+		)
+		if err := rows.Scan(
+			&d.ID, &d.EventID, &d.ChannelType, &d.RecipientHash, &d.Template,
+			&d.Provider, &d.ProviderMessageID, &d.Status, &d.Attempts,
+			&d.Error, &d.CreatedAt, &d.UpdatedAt, &sentAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		if sentAt.Valid {
+			d.SentAt = &sentAt.Time
+		}
+		result = append(result, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate deliveries: %w", err)
+	}
+	return result, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
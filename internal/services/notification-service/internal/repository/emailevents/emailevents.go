@@ -0,0 +1,39 @@
+package emailevents
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"notification-service/internal/domain"
+)
+
+type databaseManager interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type Repository struct {
+	db databaseManager
+}
+
+func New(db databaseManager) *Repository {
+	return &Repository{db: db}
+}
+
+// Record stores a provider event; events already recorded are ignored, as
+// providers redeliver webhooks until they get a 2xx.
+func (r *Repository) Record(ctx context.Context, e domain.EmailEvent) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO email_events (
+			provider_event_id, provider_message_id, recipient_hash, event, bounce_type, reason, occurred_at
+		)
+		VALUES ($1, NULLIF($2, ''), $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7)
+		ON CONFLICT (provider_event_id) DO NOTHING`,
+		e.ProviderEventID, e.ProviderMessageID, domain.HashRecipient(e.Email),
+		e.Event, e.BounceType, e.Reason, e.OccurredAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record email event %s: %w", e.ProviderEventID, err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package suppressions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"notification-service/internal/domain"
+)
+
+var ErrNotFound = errors.New("suppression not found")
+
+type databaseManager interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type Repository struct {
+	db databaseManager
+}
+
+func New(db databaseManager) *Repository {
+	return &Repository{db: db}
+}
+
+// Add suppresses s.RecipientHash, replacing any existing entry for it.
+func (r *Repository) Add(ctx context.Context, s domain.Suppression) error {
+	var expiresAt *time.Time
+	if s.ExpiresAt != nil {
+		utc := s.ExpiresAt.UTC()
+		expiresAt = &utc
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO suppressions (recipient_hash, channel_type, reason, source, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (recipient_hash) DO UPDATE
+		SET channel_type = EXCLUDED.channel_type,
+			reason = EXCLUDED.reason,
+			source = EXCLUDED.source,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at`,
+		s.RecipientHash, s.ChannelType, s.Reason, s.Source, time.Now().UTC(), expiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add suppression: %w", err)
+	}
+	return nil
+}
+
+// Lookup returns the unexpired suppression of recipientHash, or nil if the
+// recipient may be notified.
+func (r *Repository) Lookup(ctx context.Context, recipientHash string) (*domain.Suppression, error) {
+	var (
+		s         domain.Suppression
+		expiresAt sql.NullTime
+	)
+	err := r.db.QueryRowContext(ctx, `
+		SELECT recipient_hash, channel_type, reason, source, created_at, expires_at
+		FROM suppressions
+		WHERE recipient_hash = $1 AND (expires_at IS NULL OR expires_at > $2)`,
+		recipientHash, time.Now().UTC(),
+	).Scan(&s.RecipientHash, &s.ChannelType, &s.Reason, &s.Source, &s.CreatedAt, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up suppression: %w", err)
+	}
+	if expiresAt.Valid {
+		s.ExpiresAt = &expiresAt.Time
+	}
+	return &s, nil
+}
+
+// Remove lifts the suppression of recipientHash.
+func (r *Repository) Remove(ctx context.Context, recipientHash string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM suppressions WHERE recipient_hash = $1`, recipientHash)
+	if err != nil {
+		return fmt.Errorf("failed to remove suppression: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after removal: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
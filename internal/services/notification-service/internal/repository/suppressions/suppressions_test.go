@@ -0,0 +1,92 @@
+package suppressions_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"notification-service/internal/repository/suppressions"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var lookupQuery = regexp.QuoteMeta("WHERE recipient_hash = $1 AND (expires_at IS NULL OR expires_at > $2)")
+
+// aboutNow matches a time argument within a few seconds of the current time.
+type aboutNow struct{}
+
+func (aboutNow) Match(v driver.Value) bool {
+	t, ok := v.(time.Time)
+	return ok && time.Since(t).Abs() < 5*time.Second
+}
+
+var suppressionColumns = []string{"recipient_hash", "channel_type", "reason", "source", "created_at", "expires_at"}
+
+func TestLookup_IgnoresExpiredSuppressions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(lookupQuery).
+		WithArgs("hash-1", aboutNow{}).
+		WillReturnRows(sqlmock.NewRows(suppressionColumns))
+
+	suppression, err := suppressions.New(db).Lookup(context.Background(), "hash-1")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if suppression != nil {
+		t.Errorf("Lookup() = %+v, want nil for an expired or missing suppression", suppression)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLookup_ReturnsActiveSuppression(t *testing.T) {
+	createdAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := time.Now().Add(time.Hour).UTC()
+	tests := []struct {
+		name          string
+		expiresAt     driver.Value
+		wantExpiresAt *time.Time
+	}{
+		{name: "until expiry", expiresAt: expiresAt, wantExpiresAt: &expiresAt},
+		{name: "indefinite", expiresAt: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(lookupQuery).
+				WithArgs("hash-1", aboutNow{}).
+				WillReturnRows(sqlmock.NewRows(suppressionColumns).
+					AddRow("hash-1", "email", "bounce", "sendgrid", createdAt, tt.expiresAt))
+
+			suppression, err := suppressions.New(db).Lookup(context.Background(), "hash-1")
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			if suppression == nil {
+				t.Fatal("Lookup() = nil, want the suppression")
+			}
+			if suppression.Reason != "bounce" || suppression.Source != "sendgrid" || !suppression.CreatedAt.Equal(createdAt) {
+				t.Errorf("Lookup() = %+v, want the scanned row", suppression)
+			}
+			switch {
+			case tt.wantExpiresAt == nil && suppression.ExpiresAt != nil:
+				t.Errorf("ExpiresAt = %v, want nil", *suppression.ExpiresAt)
+			case tt.wantExpiresAt != nil && (suppression.ExpiresAt == nil || !suppression.ExpiresAt.Equal(*tt.wantExpiresAt)):
+				t.Errorf("ExpiresAt = %v, want %v", suppression.ExpiresAt, *tt.wantExpiresAt)
+			}
+		})
+	}
+}
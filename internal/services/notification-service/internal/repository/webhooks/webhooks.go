@@ -0,0 +1,130 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"notification-service/internal/domain"
+)
+
+var ErrNotFound = errors.New("webhook endpoint not found")
+
+type databaseManager interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Repository keeps the failure state of webhook endpoints. Endpoints get a
+// row on their first failed delivery, so healthy endpoints cost no writes.
+type Repository struct {
+	db databaseManager
+}
+
+func New(db databaseManager) *Repository {
+	return &Repository{db: db}
+}
+
+// IsDisabled reports whether url was disabled after repeated failures.
+func (r *Repository) IsDisabled(ctx context.Context, url string) (bool, error) {
+	var disabled bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT disabled_at IS NOT NULL FROM webhook_endpoints WHERE url = $1`, url,
+	).Scan(&disabled)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to look up webhook endpoint: %w", err)
+	}
+	return disabled, nil
+}
+
+// RecordSuccess resets the consecutive failures of url.
+func (r *Repository) RecordSuccess(ctx context.Context, url string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE webhook_endpoints
+		SET consecutive_failures = 0, updated_at = $2
+		WHERE url = $1 AND consecutive_failures > 0`,
+		url, time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook success: %w", err)
+	}
+	return nil
+}
+
+// RecordFailure counts a failed delivery to url and disables the endpoint once
+// disableAfter deliveries in a row have failed; zero never disables. It
+// reports whether the endpoint is disabled.
+func (r *Repository) RecordFailure(ctx context.Context, url, reason string, disableAfter int) (bool, error) {
+	var disabled bool
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO webhook_endpoints (url, consecutive_failures, last_error, disabled_at, updated_at)
+		VALUES ($1, 1, $2, CASE WHEN $3::int = 1 THEN $4::timestamp END, $4::timestamp)
+		ON CONFLICT (url) DO UPDATE
+		SET consecutive_failures = webhook_endpoints.consecutive_failures + 1,
+			last_error = EXCLUDED.last_error,
+			disabled_at = COALESCE(webhook_endpoints.disabled_at, CASE
+				WHEN $3::int > 0 AND webhook_endpoints.consecutive_failures + 1 >= $3::int THEN EXCLUDED.updated_at
+			END),
+			updated_at = EXCLUDED.updated_at
+		RETURNING disabled_at IS NOT NULL`,
+		url, reason, disableAfter, time.Now().UTC(),
+	).Scan(&disabled)
+	if err != nil {
+		return false, fmt.Errorf("failed to record webhook failure: %w", err)
+	}
+	return disabled, nil
+}
+
+// List returns every endpoint with recorded failures, most recently updated first.
+func (r *Repository) List(ctx context.Context) ([]domain.WebhookEndpoint, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT url, consecutive_failures, COALESCE(last_error, ''), disabled_at, updated_at
+		FROM webhook_endpoints
+		ORDER BY updated_at DESC, url`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook endpoints: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.WebhookEndpoint
+	for rows.Next() {
+		var (
+			e          domain.WebhookEndpoint
+			disabledAt sql.NullTime
+		)
+		if err := rows.Scan(&e.URL, &e.ConsecutiveFailures, &e.LastError, &disabledAt, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook endpoint: %w", err)
+		}
+		if disabledAt.Valid {
+			e.DisabledAt = &disabledAt.Time
+		}
+		result = append(result, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhook endpoints: %w", err)
+	}
+	return result, nil
+}
+
+// Enable forgets the failures of url, re-enabling it if it was disabled.
+func (r *Repository) Enable(ctx context.Context, url string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook_endpoints WHERE url = $1`, url)
+	if err != nil {
+		return fmt.Errorf("failed to enable webhook endpoint: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after enabling: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package templates
+
+import (
+	"strings"
+
+	"notification-service/internal/domain"
+)
+
+// sampleData is rendered through every template at load time so broken
+// templates are rejected before they reach a recipient.
+var sampleData = withSMSVariants(map[string]any{
+	domain.ConfirmTemplate: domain.ConfirmationData{
+		ConfirmToken: "00000000-0000-0000-0000-000000000000",
+	},
+	domain.WelcomeTemplate: domain.WelcomeData{
+		City:             "Kyiv",
+		UnsubscribeToken: "00000000-0000-0000-0000-000000000000",
+	},
+	domain.WeatherUpdateTemplate: domain.WeatherMetrics{
+		City:        "Kyiv",
+		Description: "Partly cloudy",
+		Temperature: 21.5,
+		Humidity:    60,
+	},
+	domain.UnsubscribeTemplate: domain.UnsubscribeData{
+		City: "Kyiv",
+	},
+	domain.SubscriptionUpdatedTemplate: domain.SubscriptionChangeData{
+		City:     "Kyiv",
+		Schedule: "daily at 08:00 (Europe/Kyiv)",
+	},
+	domain.SubscriptionPausedTemplate: domain.SubscriptionChangeData{
+		City: "Kyiv",
+	},
+	domain.SubscriptionResumedTemplate: domain.SubscriptionChangeData{
+		City:     "Kyiv",
+		Schedule: "daily at 08:00 (Europe/Kyiv)",
+	},
+	domain.ManageLinkTemplate: domain.ManageLinkData{
+		Link:      "https://example.com/manage.html#token=sample",
+		ExpiresAt: "2025-06-10 08:15 UTC",
+	},
+})
+
+// withSMSVariants registers the SMS variant of every template with the same
+// sample data.
+func withSMSVariants(samples map[string]any) map[string]any {
+	all := make(map[string]any, 2*len(samples))
+	for name, data := range samples {
+		all[name] = data
+		all[domain.SMSTemplate(name)] = data
+	}
+	return all
+}
+
+func isSMSTemplate(name string) bool {
+	return strings.HasSuffix(name, domain.SMSTemplate(""))
+}
@@ -0,0 +1,316 @@
+package templates
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+	"unicode/utf8"
+
+	"notification-service/internal/domain"
+)
+
+const (
+	subjectFile = "subject.tmpl"
+	textFile    = "body.txt.tmpl"
+	htmlFile    = "body.html.tmpl"
+
+	versionPrefix = "v"
+)
+
+// Template is one parsed version of a notification template.
+type Template struct {
+	Name    string
+	Version int
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+func (t *Template) Render(data any) (domain.RenderedMessage, error) {
+	var msg domain.RenderedMessage
+
+	var buf bytes.Buffer
+	if t.subject != nil {
+		if err := t.subject.Execute(&buf, data); err != nil {
+			return msg, fmt.Errorf("failed to render subject of %s/v%d: %w", t.Name, t.Version, err)
+		}
+		msg.Subject = strings.TrimSpace(buf.String())
+	}
+
+	buf.Reset()
+	if err := t.text.Execute(&buf, data); err != nil {
+		return msg, fmt.Errorf("failed to render text body of %s/v%d: %w", t.Name, t.Version, err)
+	}
+	msg.Text = buf.String()
+
+	if t.html != nil {
+		buf.Reset()
+		if err := t.html.Execute(&buf, data); err != nil {
+			return msg, fmt.Errorf("failed to render html body of %s/v%d: %w", t.Name, t.Version, err)
+		}
+		msg.HTML = buf.String()
+	}
+	return msg, nil
+}
+
+// Store serves the active version of every template found under dir, laid out
+// as <dir>/<name>/v<N>/{subject.tmpl,body.txt.tmpl,body.html.tmpl}; only the
+// text body is required. The highest version is active unless pinned in versions.
+type Store struct {
+	dir      string
+	versions map[string]int
+
+	mu        sync.RWMutex
+	templates map[string]*Template
+	modTime   time.Time
+}
+
+// NewStore loads and validates all templates, failing if any required
+// template is missing or does not render against its sample data.
+func NewStore(dir string, versions map[string]int) (*Store, error) {
+	s := &Store{
+		dir:      dir,
+		versions: versions,
+	}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Store) Get(name string) (*Template, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tpl, ok := s.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("template '%s' not found", name)
+	}
+	return tpl, nil
+}
+
+func (s *Store) Render(name string, data any) (domain.RenderedMessage, error) {
+	tpl, err := s.Get(name)
+	if err != nil {
+		return domain.RenderedMessage{}, err
+	}
+	return tpl.Render(data)
+}
+
+// Reload re-reads the template directory. The current set is kept if the
+// new one fails to parse or validate.
+func (s *Store) Reload() error {
+	modTime, err := latestModTime(s.dir)
+	if err != nil {
+		return fmt.Errorf("failed to stat templates dir %s: %w", s.dir, err)
+	}
+
+	loaded, err := load(s.dir, s.versions)
+	if err != nil {
+		return err
+	}
+	if err := Validate(loaded); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.templates = loaded
+	s.modTime = modTime
+	s.mu.Unlock()
+
+	for name, tpl := range loaded {
+		log.Printf("[Templates] Loaded %s v%d", name, tpl.Version)
+	}
+	return nil
+}
+
+// StartHotReload polls the template directory and reloads it whenever a file
+// changes, until ctx is cancelled.
+func (s *Store) StartHotReload(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			modTime, err := latestModTime(s.dir)
+			if err != nil {
+				log.Printf("[Templates] Failed to stat templates dir: %v", err)
+				continue
+			}
+			s.mu.RLock()
+			changed := modTime.After(s.modTime)
+			s.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := s.Reload(); err != nil {
+				log.Printf("[Templates] Reload failed, keeping previous templates: %v", err)
+				s.mu.Lock()
+				s.modTime = modTime
+				s.mu.Unlock()
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Validate checks that every template with sample data is present and renders.
+func Validate(templates map[string]*Template) error {
+	var errs []error
+	for name, data := range sampleData {
+		tpl, ok := templates[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("template '%s' is missing", name))
+			continue
+		}
+		msg, err := tpl.Render(data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if isSMSTemplate(name) && utf8.RuneCountInString(msg.Text) > domain.MaxSMSLength {
+			errs = append(errs, fmt.Errorf("template '%s' renders %d characters, over the %d SMS limit",
+				name, utf8.RuneCountInString(msg.Text), domain.MaxSMSLength))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("template validation failed: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func load(dir string, pinned map[string]int) (map[string]*Template, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates dir %s: %w", dir, err)
+	}
+
+	templates := make(map[string]*Template)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		versions, err := listVersions(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		if len(versions) == 0 {
+			continue
+		}
+
+		version := versions[len(versions)-1]
+		if v, ok := pinned[name]; ok {
+			if !containsVersion(versions, v) {
+				return nil, fmt.Errorf("template '%s' has no pinned version v%d", name, v)
+			}
+			version = v
+		}
+
+		tpl, err := parse(filepath.Join(dir, name, versionPrefix+strconv.Itoa(version)), name, version)
+		if err != nil {
+			return nil, err
+		}
+		templates[name] = tpl
+	}
+	return templates, nil
+}
+
+func listVersions(dir string) ([]int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template dir %s: %w", dir, err)
+	}
+	var versions []int
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), versionPrefix) {
+			continue
+		}
+		v, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), versionPrefix))
+		if err != nil || v <= 0 {
+			continue
+		}
+		versions = append(versions, v)
+	}
+	sort.Ints(versions)
+	return versions, nil
+}
+
+func containsVersion(versions []int, v int) bool {
+	for _, candidate := range versions {
+		if candidate == v {
+			return true
+		}
+	}
+	return false
+}
+
+func parse(dir, name string, version int) (*Template, error) {
+	tpl := &Template{Name: name, Version: version}
+
+	subject, err := os.ReadFile(filepath.Join(dir, subjectFile))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read subject of %s/v%d: %w", name, version, err)
+	default:
+		tpl.subject, err = texttemplate.New(subjectFile).Option("missingkey=error").Parse(string(subject))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse subject of %s/v%d: %w", name, version, err)
+		}
+	}
+
+	text, err := os.ReadFile(filepath.Join(dir, textFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read text body of %s/v%d: %w", name, version, err)
+	}
+	tpl.text, err = texttemplate.New(textFile).Option("missingkey=error").Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse text body of %s/v%d: %w", name, version, err)
+	}
+
+	html, err := os.ReadFile(filepath.Join(dir, htmlFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return tpl, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read html body of %s/v%d: %w", name, version, err)
+	}
+	tpl.html, err = htmltemplate.New(htmlFile).Option("missingkey=error").Parse(string(html))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html body of %s/v%d: %w", name, version, err)
+	}
+	return tpl, nil
+}
+
+func latestModTime(dir string) (time.Time, error) {
+	var latest time.Time
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	return latest, err
+}
@@ -0,0 +1,167 @@
+package templates_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"notification-service/internal/domain"
+	"notification-service/internal/templates"
+)
+
+func writeTemplate(t *testing.T, dir, name string, version int, subject, text, html string) {
+	t.Helper()
+	versionDir := filepath.Join(dir, name, "v"+strconv.Itoa(version))
+	if err := os.MkdirAll(versionDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"subject.tmpl":   subject,
+		"body.txt.tmpl":  text,
+		"body.html.tmpl": html,
+	}
+	for file, content := range files {
+		if content == "" {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(versionDir, file), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func writeRequired(t *testing.T, dir string) {
+	t.Helper()
+	writeTemplate(t, dir, domain.ConfirmTemplate, 1, "Confirm", "Code: {{ .ConfirmToken }}", "")
+	writeTemplate(t, dir, domain.WelcomeTemplate, 1, "Welcome to {{ .City }}", "{{ .UnsubscribeToken }}", "")
+	writeTemplate(t, dir, domain.UnsubscribeTemplate, 1, "Bye {{ .City }}", "Bye {{ .City }}", "")
+	writeTemplate(t, dir, domain.SubscriptionUpdatedTemplate, 1, "Updated {{ .City }}", "{{ .Schedule }}", "")
+	writeTemplate(t, dir, domain.SubscriptionPausedTemplate, 1, "Paused {{ .City }}", "Paused", "")
+	writeTemplate(t, dir, domain.SubscriptionResumedTemplate, 1, "Resumed {{ .City }}", "{{ .Schedule }}", "")
+	writeTemplate(t, dir, domain.ManageLinkTemplate, 1, "Manage", "{{ .Link }}", "")
+	writeTemplate(t, dir, domain.WeatherUpdateTemplate, 1,
+		"Weather for {{ .City }}",
+		`{{ .City }}: {{ printf "%.1f" .Temperature }}`,
+		"<p>{{ .Description }}</p>",
+	)
+	for _, name := range []string{
+		domain.ConfirmTemplate, domain.WelcomeTemplate, domain.UnsubscribeTemplate, domain.WeatherUpdateTemplate,
+		domain.SubscriptionUpdatedTemplate, domain.SubscriptionPausedTemplate, domain.SubscriptionResumedTemplate,
+		domain.ManageLinkTemplate,
+	} {
+		writeTemplate(t, dir, domain.SMSTemplate(name), 1, "", "sms", "")
+	}
+}
+
+func TestNewStore_RendersLatestVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeRequired(t, dir)
+	writeTemplate(t, dir, domain.WeatherUpdateTemplate, 2, "v2 {{ .City }}", "v2 text", "<p>v2 {{ .Description }}</p>")
+
+	store, err := templates.NewStore(dir, nil)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	msg, err := store.Render(domain.WeatherUpdateTemplate, domain.WeatherMetrics{City: "Kyiv", Description: "<b>Sunny</b>"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if msg.Subject != "v2 Kyiv" {
+		t.Errorf("Subject = %q, want %q", msg.Subject, "v2 Kyiv")
+	}
+	if !strings.Contains(msg.HTML, "&lt;b&gt;Sunny&lt;/b&gt;") {
+		t.Errorf("HTML = %q, want escaped description", msg.HTML)
+	}
+}
+
+func TestNewStore_PinnedVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeRequired(t, dir)
+	writeTemplate(t, dir, domain.WeatherUpdateTemplate, 2, "v2 {{ .City }}", "v2 text", "")
+
+	store, err := templates.NewStore(dir, map[string]int{domain.WeatherUpdateTemplate: 1})
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	tpl, err := store.Get(domain.WeatherUpdateTemplate)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if tpl.Version != 1 {
+		t.Errorf("Version = %d, want 1", tpl.Version)
+	}
+}
+
+func TestNewStore_RejectsInvalidTemplates(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, dir string)
+	}{
+		{
+			name: "missing required template",
+			setup: func(t *testing.T, dir string) {
+				writeTemplate(t, dir, domain.ConfirmTemplate, 1, "Confirm", "{{ .ConfirmToken }}", "")
+			},
+		},
+		{
+			name: "unknown field",
+			setup: func(t *testing.T, dir string) {
+				writeRequired(t, dir)
+				writeTemplate(t, dir, domain.ConfirmTemplate, 2, "Confirm", "{{ .Missing }}", "")
+			},
+		},
+		{
+			name: "sms over length limit",
+			setup: func(t *testing.T, dir string) {
+				writeRequired(t, dir)
+				writeTemplate(t, dir, domain.SMSTemplate(domain.UnsubscribeTemplate), 2, "",
+					"{{ .City }} "+strings.Repeat("x", domain.MaxSMSLength), "")
+			},
+		},
+		{
+			name: "parse error",
+			setup: func(t *testing.T, dir string) {
+				writeRequired(t, dir)
+				writeTemplate(t, dir, domain.UnsubscribeTemplate, 2, "{{ .City", "text", "")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			tt.setup(t, dir)
+
+			if _, err := templates.NewStore(dir, nil); err == nil {
+				t.Fatal("NewStore() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestStore_ReloadKeepsPreviousOnError(t *testing.T) {
+	dir := t.TempDir()
+	writeRequired(t, dir)
+
+	store, err := templates.NewStore(dir, nil)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	writeTemplate(t, dir, domain.ConfirmTemplate, 2, "Confirm", "{{ .Missing }}", "")
+	if err := store.Reload(); err == nil {
+		t.Fatal("Reload() error = nil, want error")
+	}
+
+	msg, err := store.Render(domain.ConfirmTemplate, domain.ConfirmationData{ConfirmToken: "abc"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if msg.Text != "Code: abc" {
+		t.Errorf("Text = %q, want %q", msg.Text, "Code: abc")
+	}
+}
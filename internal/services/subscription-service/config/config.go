@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"subscription-service/internal/schedule"
+
 	"github.com/kelseyhightower/envconfig"
 )
 
@@ -56,9 +58,15 @@ func validate(cfg *Config) error {
 	if cfg.Kafka.CommandTopic == "" {
 		errors = append(errors, "KAFKA_COMMAND_TOPIC is required")
 	}
+	if cfg.Kafka.DLQSuffix == "" && len(cfg.Kafka.DLQTopics) == 0 {
+		errors = append(errors, "KAFKA_DLQ_SUFFIX or KAFKA_DLQ_TOPICS is required")
+	}
 	if cfg.Kafka.EventTopic == "" {
 		errors = append(errors, "KAFKA_EVENT_TOPIC is required")
 	}
+	if cfg.Kafka.SuppressionTopic == "" {
+		errors = append(errors, "KAFKA_SUPPRESSION_TOPIC is required")
+	}
 
 	if p := cfg.Observability.VictoriaMetricsPort; p <= 0 || p > 65535 {
 		errors = append(errors, "VICTORIA_METRICS_PORT must be within 1-65535")
@@ -66,6 +74,36 @@ func validate(cfg *Config) error {
 	if p := cfg.Observability.GrafanaPort; p <= 0 || p > 65535 {
 		errors = append(errors, "GRAFANA_PORT must be within 1-65535")
 	}
+	if _, err := schedule.ParseCatchUpPolicy(cfg.Scheduler.CatchUpPolicy); err != nil {
+		errors = append(errors, "SCHEDULE_CATCH_UP_POLICY must be 'send_once' or 'skip'")
+	}
+	if cfg.Scheduler.MissedSlotTolerance < 0 {
+		errors = append(errors, "SCHEDULE_MISSED_SLOT_TOLERANCE must be >= 0")
+	}
+	if cfg.Scheduler.FetchConcurrency <= 0 {
+		errors = append(errors, "WEATHER_FETCH_CONCURRENCY must be > 0")
+	}
+	if cfg.Confirmation.TokenTTL <= 0 {
+		errors = append(errors, "CONFIRMATION_TOKEN_TTL must be > 0")
+	}
+	if cfg.Confirmation.PurgeInterval <= 0 {
+		errors = append(errors, "UNCONFIRMED_PURGE_INTERVAL must be > 0")
+	}
+	if cfg.Outbox.PollInterval <= 0 {
+		errors = append(errors, "OUTBOX_POLL_INTERVAL must be > 0")
+	}
+	if cfg.Outbox.BatchSize <= 0 {
+		errors = append(errors, "OUTBOX_BATCH_SIZE must be > 0")
+	}
+	if cfg.Outbox.Retention < 0 {
+		errors = append(errors, "OUTBOX_RETENTION must be >= 0")
+	}
+	if cfg.CommandLedger.TTL <= 0 {
+		errors = append(errors, "COMMAND_LEDGER_TTL must be > 0")
+	}
+	if cfg.CommandLedger.CleanupInterval <= 0 {
+		errors = append(errors, "COMMAND_LEDGER_CLEANUP_INTERVAL must be > 0")
+	}
 	
 	if len(errors) > 0 {
 		return fmt.Errorf("config validation errors:\n- %s", strings.Join(errors, "\n- "))
@@ -1,6 +1,9 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Config structures for Subscription Service
 
@@ -20,6 +23,20 @@ type KafkaConfig struct {
 	Brokers    []string `envconfig:"KAFKA_BROKERS" required:"true" default:"kafka:9092"`
 	EventTopic string   `envconfig:"KAFKA_EVENT_TOPIC" required:"true" default:"events.subscription"`
 	CommandTopic string `envconfig:"KAFKA_COMMAND_TOPIC" required:"true" default:"commands.subscription"`
+	DLQTopics    map[string]string `envconfig:"KAFKA_DLQ_TOPICS"`
+	DLQSuffix    string            `envconfig:"KAFKA_DLQ_SUFFIX" default:".dlq"`
+	// SuppressionTopic carries recipient.suppressed events from
+	// notification-service; affected subscriptions are cancelled.
+	SuppressionTopic string `envconfig:"KAFKA_SUPPRESSION_TOPIC" default:"recipient.suppressed"`
+}
+
+// DeadLetterTopic returns the DLQ topic for a source topic, preferring an
+// explicit KAFKA_DLQ_TOPICS mapping over the KAFKA_DLQ_SUFFIX convention.
+func (k KafkaConfig) DeadLetterTopic(sourceTopic string) string {
+	if topic, ok := k.DLQTopics[sourceTopic]; ok && topic != "" {
+		return topic
+	}
+	return sourceTopic + k.DLQSuffix
 }
 
 type ObservabilityConfig struct {
@@ -27,12 +44,38 @@ type ObservabilityConfig struct {
 	GrafanaPort int `envconfig:"GRAFANA_PORT" required:"true" default:"3000"`
 }
 
+type SchedulerConfig struct {
+	CatchUpPolicy       string        `envconfig:"SCHEDULE_CATCH_UP_POLICY" default:"send_once"`
+	MissedSlotTolerance time.Duration `envconfig:"SCHEDULE_MISSED_SLOT_TOLERANCE" default:"5m"`
+	FetchConcurrency    int           `envconfig:"WEATHER_FETCH_CONCURRENCY" default:"8"`
+}
+
+type ConfirmationConfig struct {
+	TokenTTL      time.Duration `envconfig:"CONFIRMATION_TOKEN_TTL" default:"24h"`
+	PurgeInterval time.Duration `envconfig:"UNCONFIRMED_PURGE_INTERVAL" default:"10m"`
+}
+
+type OutboxConfig struct {
+	PollInterval time.Duration `envconfig:"OUTBOX_POLL_INTERVAL" default:"1s"`
+	BatchSize    int           `envconfig:"OUTBOX_BATCH_SIZE" default:"100"`
+	Retention    time.Duration `envconfig:"OUTBOX_RETENTION" default:"24h"`
+}
+
+type CommandLedgerConfig struct {
+	TTL             time.Duration `envconfig:"COMMAND_LEDGER_TTL" default:"168h"`
+	CleanupInterval time.Duration `envconfig:"COMMAND_LEDGER_CLEANUP_INTERVAL" default:"1h"`
+}
+
 type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
 	Kafka    KafkaConfig
 	WeatherServiceAddr string `envconfig:"WEATHER_SERVICE_ADDR" required:"true" default:"weather-service:8081"`
 	Observability ObservabilityConfig
+	Scheduler SchedulerConfig
+	Confirmation ConfirmationConfig
+	Outbox OutboxConfig
+	CommandLedger CommandLedgerConfig
 }
 
 func (c *Config) GetDatabaseDSN() string {
@@ -17,7 +17,11 @@ import (
 	"subscription-service/config"
 	"subscription-service/internal/infrastructure"
 	"subscription-service/internal/jobs"
+	"subscription-service/internal/repository/ledger"
+	"subscription-service/internal/repository/outbox"
+	"subscription-service/internal/repository/requests"
 	"subscription-service/internal/repository/subscriptions"
+	"subscription-service/internal/schedule"
 	"subscription-service/internal/weatherclient"
 	"subscription-service/internal/handlers/subscribe-strategies"
 )
@@ -92,6 +96,9 @@ func Run(ctx context.Context, logger loggerManager) error {
 	}
 
 	repo := subscriptions.New(dbManager.GetDB())
+	requestRepo := requests.New(dbManager.GetDB())
+	mux.Handle("GET /requests/{id}", handlers.NewRequestStatusHandler(requestRepo))
+	mux.Handle("GET /subscriptions", handlers.NewSubscriptionListHandler(repo))
 	publisher := infrastructure.NewKafkaPublisher(cfg.Kafka.Brokers, cfg.Kafka.EventTopic)
 	defer func() {
 		if err := publisher.Close(); err != nil {
@@ -101,17 +108,31 @@ func Run(ctx context.Context, logger loggerManager) error {
 
 	topics := []string{cfg.Kafka.CommandTopic}
 
+	strategyRepo := subscribestrategies.NewTxRepository(repo)
 	strategySelector := func(cmd string) (subscribestrategies.CommandStrategy, error) {
-		return subscribestrategies.StrategyFactory(cmd, repo, publisher, logger)
+		return subscribestrategies.StrategyFactory(cmd, strategyRepo, logger, subscribestrategies.StrategyConfig{
+			ConfirmationTTL: cfg.Confirmation.TokenTTL,
+			LedgerTTL:       cfg.CommandLedger.TTL,
+		})
 	}
 
+	deadLetters := infrastructure.NewDeadLetterPublisher(cfg.Kafka.Brokers, cfg.Kafka.DeadLetterTopic)
+	defer func() {
+		if err := deadLetters.Close(); err != nil {
+			logger.Errorf("dead letter publisher close error: %v", err)
+		}
+	}()
+
 	consumer := infrastructure.NewKafkaConsumer(
 		cfg.Kafka.Brokers,
 		topics,
 		subscriptionServiceGroupID,
 		logger,
 		strategySelector,
+		deadLetters,
+		requestRepo,
 	)
+	consumer.HandleTopic(cfg.Kafka.SuppressionTopic, handlers.NewSuppressionHandler(repo, logger).Handle)
 	consumerDone := consumer.Start(ctx)
 
 	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
@@ -123,9 +144,42 @@ func Run(ctx context.Context, logger loggerManager) error {
 		return fmt.Errorf("failed to init weather client: %w", err)
 	}
 
-	weatherJob := jobs.NewWeatherUpdateJob(repo, publisher, weatherClient, logger)
+	catchUpPolicy, err := schedule.ParseCatchUpPolicy(cfg.Scheduler.CatchUpPolicy)
+	if err != nil {
+		return fmt.Errorf("parse catch-up policy: %w", err)
+	}
+
+	weatherJob := jobs.NewWeatherUpdateJob(
+		repo,
+		publisher,
+		weatherClient,
+		logger,
+		jobs.WeatherUpdateJobConfig{
+			CatchUpPolicy:       catchUpPolicy,
+			MissedSlotTolerance: cfg.Scheduler.MissedSlotTolerance,
+			FetchConcurrency:    cfg.Scheduler.FetchConcurrency,
+		},
+	)
 	go weatherJob.StartPeriodic(ctx)
 
+	purgeJob := jobs.NewPurgeUnconfirmedJob(repo, logger, cfg.Confirmation.PurgeInterval)
+	go purgeJob.StartPeriodic(ctx)
+
+	ledgerCleanup := jobs.NewLedgerCleanupJob(ledger.New(dbManager.GetDB()), logger, cfg.CommandLedger.CleanupInterval)
+	go ledgerCleanup.StartPeriodic(ctx)
+
+	outboxRelay := jobs.NewOutboxRelayJob(
+		outbox.New(dbManager.GetDB()),
+		publisher,
+		logger,
+		jobs.OutboxRelayJobConfig{
+			PollInterval: cfg.Outbox.PollInterval,
+			BatchSize:    cfg.Outbox.BatchSize,
+			Retention:    cfg.Outbox.Retention,
+		},
+	)
+	go outboxRelay.StartPeriodic(ctx)
+
 	logger.Infof("Subscription Service is running.")
 
 	<-ctx.Done()
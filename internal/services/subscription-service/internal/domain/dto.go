@@ -1,21 +1,61 @@
 package domain
 
+// ReplayedFailureError is returned for a command whose ID was already
+// processed and failed; Error reports the original failure reason. Rejected
+// is set when that failure was a rejection of the command itself.
+type ReplayedFailureError struct {
+	Reason   string
+	Rejected bool
+}
+
+func (e *ReplayedFailureError) Error() string {
+	return e.Reason
+}
+
 type SubscriptionCommand struct {
-	Command          string `json:"command"` // subscribe, confirm, unsubscribe
+	CommandID        string `json:"command_id,omitempty"`
+	RequestID        string `json:"request_id,omitempty"`
+	Command          string `json:"command"` // subscribe, confirm, unsubscribe, update, pause, resume, manage_link
 	ChannelType      string `json:"channel_type"`
 	ChannelValue     string `json:"channel_value"`
 	City             string `json:"city"`
 	FrequencyMinutes int    `json:"frequency_minutes"`
+	DeliveryTime     string `json:"delivery_time,omitempty"`
+	DeliveryDays     string `json:"delivery_days,omitempty"`
+	Timezone         string `json:"timezone,omitempty"`
 	Token            string `json:"token"`
+	// Link and LinkExpiresAt carry the signed manage link of a manage_link
+	// command.
+	Link          string `json:"link,omitempty"`
+	LinkExpiresAt int64  `json:"link_expires_at,omitempty"`
 }
 
 type SubscriptionEvent struct {
+	EventID          string `json:"event_id"`
 	EventType        string `json:"event_type"`
 	ChannelType      string `json:"channel_type"`
 	ChannelValue     string `json:"channel_value"`
 	City             string `json:"city"`
 	FrequencyMinutes int    `json:"frequency_minutes,omitempty"`
+	DeliveryTime     string `json:"delivery_time,omitempty"`
+	DeliveryDays     string `json:"delivery_days,omitempty"`
+	Timezone         string `json:"timezone,omitempty"`
 	Token            string `json:"token,omitempty"`
+	ConfirmedAt      int64  `json:"confirmed_at,omitempty"`
+	Link             string `json:"link,omitempty"`
+	LinkExpiresAt    int64  `json:"link_expires_at,omitempty"`
+}
+
+// RecipientSuppressedEvent is published by notification-service when a
+// recipient hard-bounces or reports spam.
+type RecipientSuppressedEvent struct {
+	EventID      string `json:"event_id"`
+	EventType    string `json:"event_type"`
+	ChannelType  string `json:"channel_type"`
+	ChannelValue string `json:"channel_value"`
+	Reason       string `json:"reason"`
+	Source       string `json:"source"`
+	OccurredAt   int64  `json:"occurred_at"`
 }
 
 type WeatherMetrics struct {
@@ -26,7 +66,9 @@ type WeatherMetrics struct {
 }
 
 type WeatherUpdateEvent struct {
+	EventID   string			`json:"event_id"`
 	Metrics   WeatherMetrics	`json:"metrics"`
 	UpdatedAt int64				`json:"updated_at"`
 	Email     string			`json:"channel_value"`
+	ChannelType string			`json:"channel_type"`
 }
\ No newline at end of file
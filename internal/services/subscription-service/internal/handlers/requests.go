@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"subscription-service/internal/repository/requests"
+
+	"github.com/google/uuid"
+)
+
+const requestLookupTimeout = 3 * time.Second
+
+type requestRepositoryManager interface {
+	GetByID(ctx context.Context, requestID string) (*requests.Request, error)
+}
+
+type RequestStatusResponse struct {
+	RequestID string    `json:"request_id"`
+	Command   string    `json:"command"`
+	Status    string    `json:"status"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type RequestStatusHandler struct {
+	repo requestRepositoryManager
+}
+
+func NewRequestStatusHandler(repo requestRepositoryManager) *RequestStatusHandler {
+	return &RequestStatusHandler{repo: repo}
+}
+
+func (h *RequestStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestID := r.PathValue("id")
+	if _, err := uuid.Parse(requestID); err != nil {
+		http.Error(w, "invalid request id", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), requestLookupTimeout)
+	defer cancel()
+	req, err := h.repo.GetByID(ctx, requestID)
+	if errors.Is(err, requests.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("failed to look up request %s: %v", requestID, err)
+		http.Error(w, "failed to look up request", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(RequestStatusResponse{
+		RequestID: req.RequestID,
+		Command:   req.Command,
+		Status:    req.Status,
+		Reason:    req.Reason,
+		CreatedAt: req.CreatedAt,
+		UpdatedAt: req.UpdatedAt,
+	}); err != nil {
+		log.Printf("failed to write response: %v", err)
+	}
+}
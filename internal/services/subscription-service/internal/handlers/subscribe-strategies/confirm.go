@@ -4,18 +4,49 @@ import (
 	"context"
 	"fmt"
 	"subscription-service/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type ConfirmStrategy struct {
-	repo   subscriptionRepositoryManager
+	ledger commandLedger
 	logger loggerManager
 }
 
 func (c *ConfirmStrategy) Execute(ctx context.Context, cmd domain.SubscriptionCommand) error {
 	c.logger.Infof("Handling confirm command: %+v", cmd)
-	if err := c.repo.ConfirmByToken(ctx, cmd.Token); err != nil {
-		c.logger.Errorf("Failed to confirm subscription: %v", err)
-		return fmt.Errorf("failed to confirm subscription: %w", err)
+	err := c.ledger.execute(ctx, cmd, func(tx subscriptionTxManager) error {
+		if err := tx.ConfirmByToken(ctx, cmd.Token); err != nil {
+			c.logger.Errorf("Failed to confirm subscription: %v", err)
+			return fmt.Errorf("failed to confirm subscription: %w", err)
+		}
+
+		sub, err := tx.GetSubscriptionByToken(ctx, cmd.Token)
+		if err != nil {
+			c.logger.Errorf("Failed to get subscription by token: %v", err)
+			return fmt.Errorf("failed to get subscription by token '%s': %w", cmd.Token, err)
+		}
+
+		event := domain.SubscriptionEvent{
+			EventID:          uuid.NewString(),
+			EventType:        "subscription.confirmed",
+			ChannelType:      sub.ChannelType,
+			ChannelValue:     sub.ChannelValue,
+			City:             sub.City,
+			FrequencyMinutes: sub.FrequencyMinutes,
+			Token:            sub.Token,
+			ConfirmedAt:      time.Now().Unix(),
+		}
+		c.logger.Infof("Enqueueing event: %+v", event)
+		if err := tx.EnqueueEvent(ctx, "subscription.confirmed", sub.ChannelValue, event); err != nil {
+			c.logger.Errorf("Failed to enqueue event: %v", err)
+			return fmt.Errorf("failed to enqueue confirmation event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 	c.logger.Infof("Confirm command handled successfully for token=%s", cmd.Token)
 	return nil
@@ -1,36 +1,76 @@
 package subscribestrategies
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 const (
-	subscribeCommand = "subscribe"
-	confirmCommand   = "confirm"
+	subscribeCommand   = "subscribe"
+	confirmCommand     = "confirm"
 	unsubscribeCommand = "unsubscribe"
+	updateCommand      = "update"
+	pauseCommand       = "pause"
+	resumeCommand      = "resume"
+	manageLinkCommand  = "manage_link"
+
+	defaultTimezone = "UTC"
 )
 
+type StrategyConfig struct {
+	// ConfirmationTTL is how long a confirmation token stays valid.
+	ConfirmationTTL time.Duration
+	// LedgerTTL is how long processed command IDs are remembered.
+	LedgerTTL time.Duration
+}
+
 func StrategyFactory(
 	cmd string,
 	repo subscriptionRepositoryManager,
-	publisher eventPublisherManager,
 	logger loggerManager,
+	cfg StrategyConfig,
 ) (CommandStrategy, error) {
+	ledger := commandLedger{
+		repo:   repo,
+		logger: logger,
+		ttl:    cfg.LedgerTTL,
+	}
 	switch cmd {
 	case subscribeCommand:
 		return &SubscribeStrategy{
-			repo:      repo,
-			publisher: publisher,
-			logger:    logger,
+			ledger:          ledger,
+			logger:          logger,
+			confirmationTTL: cfg.ConfirmationTTL,
 		}, nil
 	case confirmCommand:
 		return &ConfirmStrategy{
-			repo:   repo,
+			ledger: ledger,
 			logger: logger,
 		}, nil
 	case unsubscribeCommand:
 		return &UnsubscribeStrategy{
-			repo:      repo,
-			publisher: publisher,
-			logger:    logger,
+			ledger: ledger,
+			logger: logger,
+		}, nil
+	case updateCommand:
+		return &UpdateStrategy{
+			ledger: ledger,
+			logger: logger,
+		}, nil
+	case pauseCommand:
+		return &PauseStrategy{
+			ledger: ledger,
+			logger: logger,
+		}, nil
+	case resumeCommand:
+		return &ResumeStrategy{
+			ledger: ledger,
+			logger: logger,
+		}, nil
+	case manageLinkCommand:
+		return &ManageLinkStrategy{
+			ledger: ledger,
+			logger: logger,
 		}, nil
 	default:
 		return nil, fmt.Errorf("unknown command: %s", cmd)
@@ -0,0 +1,171 @@
+package subscribestrategies
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"subscription-service/internal/repository/ledger"
+	"subscription-service/internal/repository/subscriptions"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Infof(string, ...interface{})  {}
+func (nopLogger) Errorf(string, ...interface{}) {}
+func (nopLogger) Debugf(string, ...interface{}) {}
+
+type outboxEvent struct {
+	topic string
+	key   string
+	event any
+}
+
+// fakeRepository keeps subscriptions, ledger entries and outbox events in
+// memory. WithTx works on a copy that replaces the state only when fn
+// succeeds, mirroring a rolled back transaction.
+type fakeRepository struct {
+	subs     map[string]subscriptions.Subscription
+	commands map[string]ledger.Entry
+	events   []outboxEvent
+}
+
+func newFakeRepository(subs ...subscriptions.Subscription) *fakeRepository {
+	r := &fakeRepository{
+		subs:     map[string]subscriptions.Subscription{},
+		commands: map[string]ledger.Entry{},
+	}
+	for _, s := range subs {
+		r.subs[s.Token] = s
+	}
+	return r
+}
+
+func (r *fakeRepository) WithTx(_ context.Context, fn func(tx subscriptionTxManager) error) error {
+	tx := &fakeRepository{
+		subs:     maps.Clone(r.subs),
+		commands: maps.Clone(r.commands),
+		events:   append([]outboxEvent(nil), r.events...),
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	*r = *tx
+	return nil
+}
+
+func (r *fakeRepository) CreateSubscription(_ context.Context, sub *subscriptions.Subscription) error {
+	for _, s := range r.subs {
+		if s.ChannelType == sub.ChannelType && s.ChannelValue == sub.ChannelValue && s.City == sub.City {
+			return subscriptions.ErrAlreadySubscribed
+		}
+	}
+	r.subs[sub.Token] = *sub
+	return nil
+}
+
+// RefreshUnconfirmed moves the unconfirmed subscription for the channel and
+// city of sub to the token of sub, like the rotating UPDATE of the repository.
+func (r *fakeRepository) RefreshUnconfirmed(_ context.Context, sub *subscriptions.Subscription) error {
+	for token, s := range r.subs {
+		if s.ChannelType == sub.ChannelType && s.ChannelValue == sub.ChannelValue && s.City == sub.City && !s.Confirmed {
+			delete(r.subs, token)
+			r.subs[sub.Token] = *sub
+			return nil
+		}
+	}
+	return subscriptions.ErrSubscriptionNotFound
+}
+
+func (r *fakeRepository) ConfirmByToken(_ context.Context, token string) error {
+	s, ok := r.subs[token]
+	if !ok {
+		return subscriptions.ErrSubscriptionNotFound
+	}
+	s.Confirmed = true
+	r.subs[token] = s
+	return nil
+}
+
+func (r *fakeRepository) UnsubscribeByToken(_ context.Context, token string) error {
+	if _, ok := r.subs[token]; !ok {
+		return subscriptions.ErrSubscriptionNotFound
+	}
+	delete(r.subs, token)
+	return nil
+}
+
+func (r *fakeRepository) UpdateSchedule(_ context.Context, sub *subscriptions.Subscription) error {
+	if _, ok := r.subs[sub.Token]; !ok {
+		return subscriptions.ErrSubscriptionNotFound
+	}
+	r.subs[sub.Token] = *sub
+	return nil
+}
+
+func (r *fakeRepository) PauseByToken(_ context.Context, token string) error {
+	s, ok := r.subs[token]
+	if !ok {
+		return subscriptions.ErrSubscriptionNotFound
+	}
+	if s.PausedAt != nil {
+		return subscriptions.ErrAlreadyPaused
+	}
+	now := time.Now().UTC()
+	s.PausedAt = &now
+	r.subs[token] = s
+	return nil
+}
+
+func (r *fakeRepository) ResumeByToken(_ context.Context, token string, next time.Time) error {
+	s, ok := r.subs[token]
+	if !ok {
+		return subscriptions.ErrSubscriptionNotFound
+	}
+	if s.PausedAt == nil {
+		return subscriptions.ErrNotPaused
+	}
+	s.PausedAt = nil
+	s.NextNotifiedAt = next
+	r.subs[token] = s
+	return nil
+}
+
+func (r *fakeRepository) GetSubscriptionByToken(_ context.Context, token string) (*subscriptions.Subscription, error) {
+	s, ok := r.subs[token]
+	if !ok {
+		return nil, subscriptions.ErrSubscriptionNotFound
+	}
+	return &s, nil
+}
+
+func (r *fakeRepository) ListByChannelValue(_ context.Context, channelType, channelValue string) ([]subscriptions.Subscription, error) {
+	var result []subscriptions.Subscription
+	for _, s := range r.subs {
+		if s.ChannelType == channelType && s.ChannelValue == channelValue {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepository) EnqueueEvent(_ context.Context, topic, key string, event any) error {
+	r.events = append(r.events, outboxEvent{topic: topic, key: key, event: event})
+	return nil
+}
+
+func (r *fakeRepository) LookupCommand(_ context.Context, commandID string) (*ledger.Entry, error) {
+	e, ok := r.commands[commandID]
+	if !ok {
+		return nil, nil
+	}
+	return &e, nil
+}
+
+func (r *fakeRepository) RecordCommand(_ context.Context, e ledger.Entry) error {
+	if _, ok := r.commands[e.CommandID]; ok {
+		return ledger.ErrAlreadyRecorded
+	}
+	r.commands[e.CommandID] = e
+	return nil
+}
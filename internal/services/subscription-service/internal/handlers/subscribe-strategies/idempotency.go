@@ -0,0 +1,146 @@
+package subscribestrategies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"subscription-service/internal/domain"
+	"subscription-service/internal/repository/ledger"
+	"subscription-service/internal/repository/subscriptions"
+	"time"
+)
+
+// commandLedger makes strategies safe to replay: the outcome of every command
+// ID is recorded, and redelivered commands return the original outcome
+// instead of being applied again.
+type commandLedger struct {
+	repo   subscriptionRepositoryManager
+	logger loggerManager
+	ttl    time.Duration
+}
+
+// replayedCommand aborts the transaction when the command was already processed.
+type replayedCommand struct {
+	entry *ledger.Entry
+}
+
+func (r *replayedCommand) Error() string {
+	return fmt.Sprintf("command %s already processed", r.entry.CommandID)
+}
+
+// execute runs fn inside a transaction that also records the command as
+// succeeded. Permanent failures are recorded separately so that replays fail
+// the same way without touching subscriptions.
+func (l commandLedger) execute(ctx context.Context, cmd domain.SubscriptionCommand, fn func(tx subscriptionTxManager) error) error {
+	id := commandIDOf(cmd)
+	if id == "" {
+		return l.repo.WithTx(ctx, fn)
+	}
+
+	err := l.repo.WithTx(ctx, func(tx subscriptionTxManager) error {
+		entry, err := tx.LookupCommand(ctx, id)
+		if err != nil {
+			return err
+		}
+		if entry != nil {
+			return &replayedCommand{entry: entry}
+		}
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return tx.RecordCommand(ctx, l.entry(id, cmd.Command, ledger.OutcomeSucceeded, ""))
+	})
+
+	var replay *replayedCommand
+	if errors.As(err, &replay) {
+		l.logger.Infof("Command %s already processed at %s with outcome %s", id, replay.entry.ProcessedAt, replay.entry.Outcome)
+		if replay.entry.Outcome == ledger.OutcomeSucceeded {
+			return nil
+		}
+		return &domain.ReplayedFailureError{Reason: replay.entry.Reason, Rejected: isRejectedReason(replay.entry.Reason)}
+	}
+	if errors.Is(err, ledger.ErrAlreadyRecorded) {
+		// A concurrent delivery won the race; the retry will replay its outcome.
+		return fmt.Errorf("failed to record command %s: %w", id, err)
+	}
+	if err != nil && IsPermanent(err) {
+		if recErr := l.repo.RecordCommand(ctx, l.entry(id, cmd.Command, ledger.OutcomeFailed, rootCause(err).Error())); recErr != nil {
+			l.logger.Errorf("Failed to record failed command %s: %v", id, recErr)
+		}
+	}
+	return err
+}
+
+func (l commandLedger) entry(id, command, outcome, reason string) ledger.Entry {
+	return ledger.Entry{
+		CommandID: id,
+		Command:   command,
+		Outcome:   outcome,
+		Reason:    reason,
+		ExpiresAt: time.Now().Add(l.ttl),
+	}
+}
+
+// commandIDOf returns the idempotency key of cmd. Commands published before
+// command IDs existed fall back to their request ID.
+func commandIDOf(cmd domain.SubscriptionCommand) string {
+	if cmd.CommandID != "" {
+		return cmd.CommandID
+	}
+	return cmd.RequestID
+}
+
+// IsPermanent reports whether err would recur on every replay of the command,
+// so retrying it cannot help. A replayed failure is permanent until its ledger
+// entry expires.
+func IsPermanent(err error) bool {
+	var replayed *domain.ReplayedFailureError
+	return errors.As(err, &replayed) || errors.Is(err, ErrInvalidCommand) || IsRejected(err)
+}
+
+// rejectedErrors are the failures caused by what the subscriber asked for
+// rather than by the message.
+var rejectedErrors = []error{
+	subscriptions.ErrAlreadySubscribed,
+	subscriptions.ErrSubscriptionNotFound,
+	subscriptions.ErrTokenExpired,
+	subscriptions.ErrAlreadyPaused,
+	subscriptions.ErrNotPaused,
+}
+
+// IsRejected reports whether err is a permanent failure caused by the request
+// itself, such as an unknown or expired token. The failed request outcome is
+// all the subscriber needs, so such commands are not dead-lettered.
+func IsRejected(err error) bool {
+	var replayed *domain.ReplayedFailureError
+	if errors.As(err, &replayed) {
+		return replayed.Rejected
+	}
+	for _, rejected := range rejectedErrors {
+		if errors.Is(err, rejected) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRejectedReason reports whether a failure reason recorded in the ledger is
+// one of rejectedErrors.
+func isRejectedReason(reason string) bool {
+	for _, rejected := range rejectedErrors {
+		if rejected.Error() == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func rootCause(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
@@ -0,0 +1,103 @@
+package subscribestrategies
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"subscription-service/internal/domain"
+	"subscription-service/internal/repository/ledger"
+	"subscription-service/internal/repository/subscriptions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCommandLedger(repo *fakeRepository) commandLedger {
+	return commandLedger{repo: repo, logger: nopLogger{}, ttl: time.Hour}
+}
+
+func TestCommandLedger_ReplayedSuccessIsNotAppliedAgain(t *testing.T) {
+	repo := newFakeRepository()
+	l := newCommandLedger(repo)
+	cmd := domain.SubscriptionCommand{CommandID: "cmd-1", Command: pauseCommand}
+
+	calls := 0
+	fn := func(tx subscriptionTxManager) error {
+		calls++
+		return tx.EnqueueEvent(context.Background(), "subscription.paused", "key", nil)
+	}
+	require.NoError(t, l.execute(context.Background(), cmd, fn))
+	require.NoError(t, l.execute(context.Background(), cmd, fn))
+
+	assert.Equal(t, 1, calls)
+	assert.Len(t, repo.events, 1)
+	assert.Equal(t, ledger.OutcomeSucceeded, repo.commands["cmd-1"].Outcome)
+}
+
+func TestCommandLedger_PermanentFailureIsReplayed(t *testing.T) {
+	repo := newFakeRepository()
+	l := newCommandLedger(repo)
+	cmd := domain.SubscriptionCommand{CommandID: "cmd-1", Command: pauseCommand}
+
+	calls := 0
+	fn := func(tx subscriptionTxManager) error {
+		calls++
+		if err := tx.EnqueueEvent(context.Background(), "subscription.paused", "key", nil); err != nil {
+			return err
+		}
+		return tx.PauseByToken(context.Background(), "unknown")
+	}
+	err := l.execute(context.Background(), cmd, fn)
+	require.ErrorIs(t, err, subscriptions.ErrSubscriptionNotFound)
+	assert.Empty(t, repo.events, "the failed transaction must roll back")
+	assert.Equal(t, ledger.OutcomeFailed, repo.commands["cmd-1"].Outcome)
+
+	err = l.execute(context.Background(), cmd, fn)
+	var replayed *domain.ReplayedFailureError
+	require.ErrorAs(t, err, &replayed)
+	assert.Equal(t, subscriptions.ErrSubscriptionNotFound.Error(), replayed.Reason)
+	assert.True(t, IsPermanent(err))
+	assert.True(t, IsRejected(err), "a replayed rejection is still a rejection")
+	assert.Equal(t, 1, calls)
+}
+
+func TestCommandLedger_TransientFailureIsNotRecorded(t *testing.T) {
+	repo := newFakeRepository()
+	l := newCommandLedger(repo)
+	cmd := domain.SubscriptionCommand{CommandID: "cmd-1", Command: pauseCommand}
+
+	transient := errors.New("connection reset")
+	err := l.execute(context.Background(), cmd, func(subscriptionTxManager) error { return transient })
+	require.ErrorIs(t, err, transient)
+	assert.False(t, IsPermanent(err))
+	assert.Empty(t, repo.commands)
+
+	calls := 0
+	require.NoError(t, l.execute(context.Background(), cmd, func(subscriptionTxManager) error {
+		calls++
+		return nil
+	}))
+	assert.Equal(t, 1, calls, "a retry after a transient failure must run the command")
+}
+
+func TestCommandLedger_FallsBackToRequestID(t *testing.T) {
+	repo := newFakeRepository()
+	l := newCommandLedger(repo)
+
+	cmd := domain.SubscriptionCommand{RequestID: "req-1", Command: pauseCommand}
+	require.NoError(t, l.execute(context.Background(), cmd, func(subscriptionTxManager) error { return nil }))
+	assert.Contains(t, repo.commands, "req-1")
+
+	cmd = domain.SubscriptionCommand{Command: pauseCommand}
+	calls := 0
+	for i := 0; i < 2; i++ {
+		require.NoError(t, l.execute(context.Background(), cmd, func(subscriptionTxManager) error {
+			calls++
+			return nil
+		}))
+	}
+	assert.Equal(t, 2, calls, "commands without an ID are not deduplicated")
+	assert.Len(t, repo.commands, 1)
+}
@@ -0,0 +1,55 @@
+package subscribestrategies
+
+import (
+	"context"
+	"fmt"
+	"subscription-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// ManageLinkStrategy forwards a signed manage link to a recipient. Recipients
+// without subscriptions get nothing, so the link endpoint cannot be used to
+// mail arbitrary addresses.
+type ManageLinkStrategy struct {
+	ledger commandLedger
+	logger loggerManager
+}
+
+func (m *ManageLinkStrategy) Execute(ctx context.Context, cmd domain.SubscriptionCommand) error {
+	m.logger.Infof("Handling manage_link command: request_id=%s channel=%s", cmd.RequestID, cmd.ChannelType)
+	if cmd.ChannelValue == "" || cmd.Link == "" {
+		return fmt.Errorf("%w: manage_link requires channel_value and link", ErrInvalidCommand)
+	}
+
+	err := m.ledger.execute(ctx, cmd, func(tx subscriptionTxManager) error {
+		subs, err := tx.ListByChannelValue(ctx, cmd.ChannelType, cmd.ChannelValue)
+		if err != nil {
+			m.logger.Errorf("Failed to list subscriptions: %v", err)
+			return fmt.Errorf("failed to list subscriptions: %w", err)
+		}
+		if len(subs) == 0 {
+			m.logger.Infof("No subscriptions for manage link request %s, nothing to send", cmd.RequestID)
+			return nil
+		}
+
+		event := domain.SubscriptionEvent{
+			EventID:       uuid.NewString(),
+			EventType:     "subscription.manage_link",
+			ChannelType:   cmd.ChannelType,
+			ChannelValue:  cmd.ChannelValue,
+			Link:          cmd.Link,
+			LinkExpiresAt: cmd.LinkExpiresAt,
+		}
+		if err := tx.EnqueueEvent(ctx, "subscription.manage_link", cmd.ChannelValue, event); err != nil {
+			m.logger.Errorf("Failed to enqueue event: %v", err)
+			return fmt.Errorf("failed to enqueue manage link event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	m.logger.Infof("Manage link command handled successfully for request_id=%s", cmd.RequestID)
+	return nil
+}
@@ -0,0 +1,98 @@
+package subscribestrategies
+
+import (
+	"context"
+	"fmt"
+	"subscription-service/internal/domain"
+	"subscription-service/internal/schedule"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PauseStrategy stops notifications for a subscription without cancelling it.
+type PauseStrategy struct {
+	ledger commandLedger
+	logger loggerManager
+}
+
+func (p *PauseStrategy) Execute(ctx context.Context, cmd domain.SubscriptionCommand) error {
+	p.logger.Infof("Handling pause command: %+v", cmd)
+	err := p.ledger.execute(ctx, cmd, func(tx subscriptionTxManager) error {
+		if err := tx.PauseByToken(ctx, cmd.Token); err != nil {
+			p.logger.Errorf("Failed to pause subscription: %v", err)
+			return fmt.Errorf("failed to pause subscription: %w", err)
+		}
+		return enqueueStateEvent(ctx, tx, p.logger, cmd.Token, "subscription.paused")
+	})
+	if err != nil {
+		return err
+	}
+	p.logger.Infof("Pause command handled successfully for token=%s", cmd.Token)
+	return nil
+}
+
+// ResumeStrategy restarts notifications for a paused subscription from the
+// next slot of its schedule; slots missed while paused are not caught up.
+type ResumeStrategy struct {
+	ledger commandLedger
+	logger loggerManager
+}
+
+func (r *ResumeStrategy) Execute(ctx context.Context, cmd domain.SubscriptionCommand) error {
+	r.logger.Infof("Handling resume command: %+v", cmd)
+	err := r.ledger.execute(ctx, cmd, func(tx subscriptionTxManager) error {
+		sub, err := tx.GetSubscriptionByToken(ctx, cmd.Token)
+		if err != nil {
+			r.logger.Errorf("Failed to get subscription by token: %v", err)
+			return fmt.Errorf("failed to get subscription by token '%s': %w", cmd.Token, err)
+		}
+		next, err := schedule.First(schedule.Schedule{
+			FrequencyMinutes: sub.FrequencyMinutes,
+			DeliveryTime:     sub.DeliveryTime,
+			Timezone:         sub.Timezone,
+			Days:             sub.DeliveryDays,
+		}, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to compute next notification: %w", err)
+		}
+		if err := tx.ResumeByToken(ctx, cmd.Token, next); err != nil {
+			r.logger.Errorf("Failed to resume subscription: %v", err)
+			return fmt.Errorf("failed to resume subscription: %w", err)
+		}
+		return enqueueStateEvent(ctx, tx, r.logger, cmd.Token, "subscription.resumed")
+	})
+	if err != nil {
+		return err
+	}
+	r.logger.Infof("Resume command handled successfully for token=%s", cmd.Token)
+	return nil
+}
+
+// enqueueStateEvent writes a paused or resumed event for the subscription with
+// token to the outbox.
+func enqueueStateEvent(ctx context.Context, tx subscriptionTxManager, logger loggerManager, token, eventType string) error {
+	sub, err := tx.GetSubscriptionByToken(ctx, token)
+	if err != nil {
+		logger.Errorf("Failed to get subscription by token: %v", err)
+		return fmt.Errorf("failed to get subscription by token '%s': %w", token, err)
+	}
+	event := domain.SubscriptionEvent{
+		EventID:          uuid.NewString(),
+		EventType:        eventType,
+		ChannelType:      sub.ChannelType,
+		ChannelValue:     sub.ChannelValue,
+		City:             sub.City,
+		FrequencyMinutes: sub.FrequencyMinutes,
+		DeliveryTime:     sub.DeliveryTime,
+		DeliveryDays:     sub.DeliveryDays,
+		Timezone:         sub.Timezone,
+		Token:            sub.Token,
+	}
+	logger.Infof("Enqueueing event: %+v", event)
+	if err := tx.EnqueueEvent(ctx, eventType, sub.ChannelValue, event); err != nil {
+		logger.Errorf("Failed to enqueue event: %v", err)
+		return fmt.Errorf("failed to enqueue %s event: %w", eventType, err)
+	}
+	return nil
+}
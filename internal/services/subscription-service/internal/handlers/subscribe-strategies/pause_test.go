@@ -0,0 +1,114 @@
+package subscribestrategies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"subscription-service/internal/domain"
+	"subscription-service/internal/repository/ledger"
+	"subscription-service/internal/repository/subscriptions"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStrategy(t *testing.T, cmd string, repo *fakeRepository) CommandStrategy {
+	t.Helper()
+	strategy, err := StrategyFactory(cmd, repo, nopLogger{}, StrategyConfig{LedgerTTL: time.Hour})
+	require.NoError(t, err)
+	return strategy
+}
+
+func testSubscription(token string, pausedAt *time.Time) subscriptions.Subscription {
+	return subscriptions.Subscription{
+		ChannelType:      "email",
+		ChannelValue:     "user@example.com",
+		City:             "Kyiv",
+		FrequencyMinutes: 60,
+		Confirmed:        true,
+		Token:            token,
+		Timezone:         "UTC",
+		PausedAt:         pausedAt,
+	}
+}
+
+func TestPauseStrategy(t *testing.T) {
+	token := uuid.NewString()
+	pausedAt := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name      string
+		sub       *subscriptions.Subscription
+		wantErr   error
+		wantEvent bool
+	}{
+		{name: "active subscription", sub: ptr(testSubscription(token, nil)), wantEvent: true},
+		{name: "already paused", sub: ptr(testSubscription(token, &pausedAt)), wantErr: subscriptions.ErrAlreadyPaused},
+		{name: "unknown token", wantErr: subscriptions.ErrSubscriptionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepository()
+			if tt.sub != nil {
+				repo = newFakeRepository(*tt.sub)
+			}
+			cmd := domain.SubscriptionCommand{CommandID: uuid.NewString(), Command: pauseCommand, Token: token}
+
+			err := newTestStrategy(t, pauseCommand, repo).Execute(context.Background(), cmd)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.True(t, IsPermanent(err))
+				assert.Equal(t, ledger.OutcomeFailed, repo.commands[cmd.CommandID].Outcome)
+				assert.Empty(t, repo.events)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, repo.subs[token].PausedAt)
+			require.Len(t, repo.events, 1)
+			assert.Equal(t, "subscription.paused", repo.events[0].topic)
+		})
+	}
+}
+
+func TestResumeStrategy(t *testing.T) {
+	token := uuid.NewString()
+	pausedAt := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name    string
+		sub     *subscriptions.Subscription
+		wantErr error
+	}{
+		{name: "paused subscription", sub: ptr(testSubscription(token, &pausedAt))},
+		{name: "not paused", sub: ptr(testSubscription(token, nil)), wantErr: subscriptions.ErrNotPaused},
+		{name: "unknown token", wantErr: subscriptions.ErrSubscriptionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepository()
+			if tt.sub != nil {
+				repo = newFakeRepository(*tt.sub)
+			}
+			cmd := domain.SubscriptionCommand{CommandID: uuid.NewString(), Command: resumeCommand, Token: token}
+
+			start := time.Now()
+			err := newTestStrategy(t, resumeCommand, repo).Execute(context.Background(), cmd)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.True(t, IsPermanent(err))
+				assert.Empty(t, repo.events)
+				return
+			}
+			require.NoError(t, err)
+			sub := repo.subs[token]
+			assert.Nil(t, sub.PausedAt)
+			assert.True(t, sub.NextNotifiedAt.After(start), "the next notification is scheduled from now")
+			require.Len(t, repo.events, 1)
+			assert.Equal(t, "subscription.resumed", repo.events[0].topic)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -2,10 +2,17 @@ package subscribestrategies
 
 import (
 	"context"
+	"errors"
 	"subscription-service/internal/domain"
+	"subscription-service/internal/repository/ledger"
 	"subscription-service/internal/repository/subscriptions"
+	"time"
 )
 
+// ErrInvalidCommand marks a command that can never be applied as sent, such as
+// one with an invalid schedule or a missing required field.
+var ErrInvalidCommand = errors.New("invalid command")
+
 type loggerManager interface {
 	Infof(format string, args ...interface{})
 	Errorf(format string, args ...interface{})
@@ -13,14 +20,45 @@ type loggerManager interface {
 }
 
 type subscriptionRepositoryManager interface {
+	WithTx(ctx context.Context, fn func(tx subscriptionTxManager) error) error
+	RecordCommand(ctx context.Context, e ledger.Entry) error
+}
+
+// TxRepository adapts a subscriptions.Repository to the strategies, whose
+// transactions work on subscriptionTxManager so that tests can pass fakes.
+type TxRepository struct {
+	repo *subscriptions.Repository
+}
+
+func NewTxRepository(repo *subscriptions.Repository) *TxRepository {
+	return &TxRepository{repo: repo}
+}
+
+func (r *TxRepository) WithTx(ctx context.Context, fn func(tx subscriptionTxManager) error) error {
+	return r.repo.WithTx(ctx, func(tx *subscriptions.Repository) error {
+		return fn(tx)
+	})
+}
+
+func (r *TxRepository) RecordCommand(ctx context.Context, e ledger.Entry) error {
+	return r.repo.RecordCommand(ctx, e)
+}
+
+// subscriptionTxManager is the transactional view of the repository passed to
+// WithTx callbacks; events are written to the outbox in the same transaction.
+type subscriptionTxManager interface {
 	CreateSubscription(ctx context.Context, sub *subscriptions.Subscription) error
+	RefreshUnconfirmed(ctx context.Context, sub *subscriptions.Subscription) error
 	ConfirmByToken(ctx context.Context, token string) error
 	UnsubscribeByToken(ctx context.Context, token string) error
+	UpdateSchedule(ctx context.Context, sub *subscriptions.Subscription) error
+	PauseByToken(ctx context.Context, token string) error
+	ResumeByToken(ctx context.Context, token string, next time.Time) error
 	GetSubscriptionByToken(ctx context.Context, token string) (*subscriptions.Subscription, error)
-}
-
-type eventPublisherManager interface {
-	PublishWithTopic(ctx context.Context, topic string, event interface{}) error
+	ListByChannelValue(ctx context.Context, channelType, channelValue string) ([]subscriptions.Subscription, error)
+	EnqueueEvent(ctx context.Context, topic, key string, event any) error
+	LookupCommand(ctx context.Context, commandID string) (*ledger.Entry, error)
+	RecordCommand(ctx context.Context, e ledger.Entry) error
 }
 
 type CommandStrategy interface {
@@ -2,48 +2,106 @@ package subscribestrategies
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"subscription-service/internal/domain"
 	"subscription-service/internal/repository/subscriptions"
+	"subscription-service/internal/schedule"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type SubscribeStrategy struct {
-	repo      subscriptionRepositoryManager
-	publisher eventPublisherManager
-	logger    loggerManager
+	ledger          commandLedger
+	logger          loggerManager
+	confirmationTTL time.Duration
 }
 
 func (s *SubscribeStrategy) Execute(ctx context.Context, cmd domain.SubscriptionCommand) error {
 	s.logger.Infof("Handling subscribe command: %+v", cmd)
+	sched := schedule.Schedule{
+		FrequencyMinutes: cmd.FrequencyMinutes,
+		DeliveryTime:     cmd.DeliveryTime,
+		Timezone:         cmd.Timezone,
+		Days:             cmd.DeliveryDays,
+	}
+	if sched.Timezone == "" {
+		sched.Timezone = defaultTimezone
+	}
+	if err := schedule.Validate(sched); err != nil {
+		s.logger.Errorf("Invalid subscription schedule: %v", err)
+		return fmt.Errorf("%w: invalid subscription schedule: %w", ErrInvalidCommand, err)
+	}
+	now := time.Now()
+	next, err := schedule.First(sched, now)
+	if err != nil {
+		return fmt.Errorf("failed to compute first notification: %w", err)
+	}
+
 	sub := &subscriptions.Subscription{
 		ChannelType:      cmd.ChannelType,
 		ChannelValue:     cmd.ChannelValue,
 		City:             cmd.City,
 		FrequencyMinutes: cmd.FrequencyMinutes,
+		DeliveryTime:     sched.DeliveryTime,
+		DeliveryDays:     sched.Days,
+		Timezone:         sched.Timezone,
+		NextNotifiedAt:   next,
 		Token:            uuid.NewString(),
+		TokenExpiresAt:   now.Add(s.confirmationTTL),
 	}
 
-	if err := s.repo.CreateSubscription(ctx, sub); err != nil {
+	err = s.ledger.execute(ctx, cmd, func(tx subscriptionTxManager) error {
+		if err := s.create(ctx, tx, sub); err != nil {
+			return err
+		}
+
+		event := domain.SubscriptionEvent{
+			EventID:          uuid.NewString(),
+			EventType:        "subscription.created",
+			ChannelType:      sub.ChannelType,
+			ChannelValue:     sub.ChannelValue,
+			City:             sub.City,
+			FrequencyMinutes: sub.FrequencyMinutes,
+			Token:            sub.Token,
+		}
+		s.logger.Infof("Enqueueing event: %+v", event)
+		if err := tx.EnqueueEvent(ctx, "subscription.created", sub.ChannelValue, event); err != nil {
+			s.logger.Errorf("Failed to enqueue event: %v", err)
+			return fmt.Errorf("failed to enqueue creation event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.Infof("Subscribe command handled successfully for token=%s", sub.Token)
+	return nil
+}
+
+// create inserts sub, or, when an unconfirmed subscription for the same
+// channel and city already exists, rotates its token so the confirmation
+// email can be sent again.
+func (s *SubscribeStrategy) create(ctx context.Context, tx subscriptionTxManager, sub *subscriptions.Subscription) error {
+	err := tx.CreateSubscription(ctx, sub)
+	if err == nil {
+		s.logger.Infof("Subscription created: %+v", sub)
+		return nil
+	}
+	if !errors.Is(err, subscriptions.ErrAlreadySubscribed) {
 		s.logger.Errorf("Failed to create subscription: %v", err)
 		return fmt.Errorf("failed to create subscription: %w", err)
 	}
-	s.logger.Infof("Subscription created: %+v", sub)
 
-	event := domain.SubscriptionEvent{
-		EventType:        "subscription.confirmed",
-		ChannelType:      sub.ChannelType,
-		ChannelValue:     sub.ChannelValue,
-		City:             sub.City,
-		FrequencyMinutes: sub.FrequencyMinutes,
-		Token:            sub.Token,
-	}
-	s.logger.Infof("Publishing event: %+v", event)
-	if err := s.publisher.PublishWithTopic(ctx, "subscription.confirmed", event); err != nil {
-		s.logger.Errorf("Failed to publish event: %v", err)
-		return fmt.Errorf("failed to publish confirmation event: %w", err)
+	if err := tx.RefreshUnconfirmed(ctx, sub); err != nil {
+		if errors.Is(err, subscriptions.ErrSubscriptionNotFound) {
+			s.logger.Infof("Subscription already confirmed for %s in %s", sub.ChannelValue, sub.City)
+			return fmt.Errorf("failed to create subscription: %w", subscriptions.ErrAlreadySubscribed)
+		}
+		s.logger.Errorf("Failed to refresh unconfirmed subscription: %v", err)
+		return fmt.Errorf("failed to refresh unconfirmed subscription: %w", err)
 	}
-	s.logger.Infof("Subscribe command handled successfully for token=%s", sub.Token)
+	s.logger.Infof("Unconfirmed subscription refreshed, resending confirmation: %+v", sub)
 	return nil
 }
@@ -0,0 +1,103 @@
+package subscribestrategies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"subscription-service/internal/domain"
+	"subscription-service/internal/repository/subscriptions"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func subscribeCmd() domain.SubscriptionCommand {
+	return domain.SubscriptionCommand{
+		CommandID:        uuid.NewString(),
+		Command:          subscribeCommand,
+		ChannelType:      "email",
+		ChannelValue:     "user@example.com",
+		City:             "Kyiv",
+		FrequencyMinutes: 60,
+	}
+}
+
+func TestSubscribeStrategy_EnqueuesCreatedEvent(t *testing.T) {
+	unconfirmed := testSubscription(uuid.NewString(), nil)
+	unconfirmed.Confirmed = false
+	tests := []struct {
+		name     string
+		existing []subscriptions.Subscription
+	}{
+		{name: "new subscription"},
+		{name: "unconfirmed subscription is refreshed", existing: []subscriptions.Subscription{unconfirmed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepository(tt.existing...)
+
+			require.NoError(t, newTestStrategy(t, subscribeCommand, repo).Execute(context.Background(), subscribeCmd()))
+
+			require.Len(t, repo.subs, 1)
+			var sub subscriptions.Subscription
+			for _, s := range repo.subs {
+				sub = s
+			}
+			assert.NotEqual(t, unconfirmed.Token, sub.Token, "the confirmation token is rotated")
+			assert.False(t, sub.Confirmed)
+
+			require.Len(t, repo.events, 1)
+			assert.Equal(t, "subscription.created", repo.events[0].topic)
+			assert.Equal(t, "user@example.com", repo.events[0].key)
+			event, ok := repo.events[0].event.(domain.SubscriptionEvent)
+			require.True(t, ok)
+			assert.Equal(t, "subscription.created", event.EventType)
+			assert.Equal(t, sub.Token, event.Token)
+			assert.Equal(t, "Kyiv", event.City)
+			assert.Equal(t, "email", event.ChannelType)
+			assert.Zero(t, event.ConfirmedAt)
+		})
+	}
+}
+
+func TestSubscribeStrategy_RejectsConfirmedDuplicate(t *testing.T) {
+	repo := newFakeRepository(testSubscription(uuid.NewString(), nil))
+
+	err := newTestStrategy(t, subscribeCommand, repo).Execute(context.Background(), subscribeCmd())
+
+	require.ErrorIs(t, err, subscriptions.ErrAlreadySubscribed)
+	assert.Empty(t, repo.events)
+}
+
+func TestConfirmStrategy_EnqueuesConfirmedEvent(t *testing.T) {
+	token := uuid.NewString()
+	sub := testSubscription(token, nil)
+	sub.Confirmed = false
+	repo := newFakeRepository(sub)
+	cmd := domain.SubscriptionCommand{CommandID: uuid.NewString(), Command: confirmCommand, Token: token}
+
+	before := time.Now().Unix()
+	require.NoError(t, newTestStrategy(t, confirmCommand, repo).Execute(context.Background(), cmd))
+
+	assert.True(t, repo.subs[token].Confirmed)
+	require.Len(t, repo.events, 1)
+	assert.Equal(t, "subscription.confirmed", repo.events[0].topic)
+	assert.Equal(t, "user@example.com", repo.events[0].key)
+	event, ok := repo.events[0].event.(domain.SubscriptionEvent)
+	require.True(t, ok)
+	assert.Equal(t, "subscription.confirmed", event.EventType)
+	assert.Equal(t, token, event.Token)
+	assert.GreaterOrEqual(t, event.ConfirmedAt, before)
+}
+
+func TestConfirmStrategy_UnknownToken(t *testing.T) {
+	repo := newFakeRepository()
+	cmd := domain.SubscriptionCommand{CommandID: uuid.NewString(), Command: confirmCommand, Token: uuid.NewString()}
+
+	err := newTestStrategy(t, confirmCommand, repo).Execute(context.Background(), cmd)
+
+	require.ErrorIs(t, err, subscriptions.ErrSubscriptionNotFound)
+	assert.Empty(t, repo.events)
+}
@@ -0,0 +1,84 @@
+package subscribestrategies
+
+import (
+	"context"
+	"fmt"
+	"subscription-service/internal/domain"
+	"subscription-service/internal/schedule"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UpdateStrategy changes the schedule of an existing subscription in place,
+// keeping its token. An empty timezone keeps the current one.
+type UpdateStrategy struct {
+	ledger commandLedger
+	logger loggerManager
+}
+
+func (u *UpdateStrategy) Execute(ctx context.Context, cmd domain.SubscriptionCommand) error {
+	u.logger.Infof("Handling update command: %+v", cmd)
+	sched := schedule.Schedule{
+		FrequencyMinutes: cmd.FrequencyMinutes,
+		DeliveryTime:     cmd.DeliveryTime,
+		Timezone:         cmd.Timezone,
+		Days:             cmd.DeliveryDays,
+	}
+	if sched.Timezone == "" {
+		sched.Timezone = defaultTimezone
+	}
+	if err := schedule.Validate(sched); err != nil {
+		u.logger.Errorf("Invalid subscription schedule: %v", err)
+		return fmt.Errorf("%w: invalid subscription schedule: %w", ErrInvalidCommand, err)
+	}
+
+	err := u.ledger.execute(ctx, cmd, func(tx subscriptionTxManager) error {
+		sub, err := tx.GetSubscriptionByToken(ctx, cmd.Token)
+		if err != nil {
+			u.logger.Errorf("Failed to get subscription by token: %v", err)
+			return fmt.Errorf("failed to get subscription by token '%s': %w", cmd.Token, err)
+		}
+		if cmd.Timezone == "" {
+			sched.Timezone = sub.Timezone
+		}
+		next, err := schedule.First(sched, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to compute next notification: %w", err)
+		}
+
+		sub.FrequencyMinutes = sched.FrequencyMinutes
+		sub.DeliveryTime = sched.DeliveryTime
+		sub.DeliveryDays = sched.Days
+		sub.Timezone = sched.Timezone
+		sub.NextNotifiedAt = next
+		if err := tx.UpdateSchedule(ctx, sub); err != nil {
+			u.logger.Errorf("Failed to update subscription: %v", err)
+			return fmt.Errorf("failed to update subscription: %w", err)
+		}
+		u.logger.Infof("Subscription updated: %+v", sub)
+
+		event := domain.SubscriptionEvent{
+			EventID:          uuid.NewString(),
+			EventType:        "subscription.updated",
+			ChannelType:      sub.ChannelType,
+			ChannelValue:     sub.ChannelValue,
+			City:             sub.City,
+			FrequencyMinutes: sub.FrequencyMinutes,
+			DeliveryTime:     sub.DeliveryTime,
+			DeliveryDays:     sub.DeliveryDays,
+			Timezone:         sub.Timezone,
+		}
+		u.logger.Infof("Enqueueing event: %+v", event)
+		if err := tx.EnqueueEvent(ctx, "subscription.updated", sub.ChannelValue, event); err != nil {
+			u.logger.Errorf("Failed to enqueue event: %v", err)
+			return fmt.Errorf("failed to enqueue update event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	u.logger.Infof("Update command handled successfully for token=%s", cmd.Token)
+	return nil
+}
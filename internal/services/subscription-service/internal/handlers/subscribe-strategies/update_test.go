@@ -0,0 +1,72 @@
+package subscribestrategies
+
+import (
+	"context"
+	"testing"
+
+	"subscription-service/internal/domain"
+	"subscription-service/internal/repository/subscriptions"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateStrategy_ChangesScheduleKeepingTimezone(t *testing.T) {
+	token := uuid.NewString()
+	sub := testSubscription(token, nil)
+	sub.Timezone = "Europe/Kyiv"
+	repo := newFakeRepository(sub)
+
+	err := newTestStrategy(t, updateCommand, repo).Execute(context.Background(), domain.SubscriptionCommand{
+		CommandID:        uuid.NewString(),
+		Command:          updateCommand,
+		Token:            token,
+		FrequencyMinutes: 1440,
+		DeliveryTime:     "07:30",
+		DeliveryDays:     "Mon-Fri",
+	})
+	require.NoError(t, err)
+
+	got := repo.subs[token]
+	assert.Equal(t, 1440, got.FrequencyMinutes)
+	assert.Equal(t, "07:30", got.DeliveryTime)
+	assert.Equal(t, "Mon-Fri", got.DeliveryDays)
+	assert.Equal(t, "Europe/Kyiv", got.Timezone)
+	assert.False(t, got.NextNotifiedAt.IsZero())
+	require.Len(t, repo.events, 1)
+	assert.Equal(t, "subscription.updated", repo.events[0].topic)
+}
+
+func TestUpdateStrategy_Errors(t *testing.T) {
+	token := uuid.NewString()
+	tests := []struct {
+		name    string
+		cmd     domain.SubscriptionCommand
+		wantErr error
+	}{
+		{
+			name:    "unknown token",
+			cmd:     domain.SubscriptionCommand{Token: uuid.NewString(), FrequencyMinutes: 60},
+			wantErr: subscriptions.ErrSubscriptionNotFound,
+		},
+		{
+			name:    "invalid schedule",
+			cmd:     domain.SubscriptionCommand{Token: token, FrequencyMinutes: 60, DeliveryTime: "07:30"},
+			wantErr: ErrInvalidCommand,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepository(testSubscription(token, nil))
+			tt.cmd.CommandID = uuid.NewString()
+			tt.cmd.Command = updateCommand
+
+			err := newTestStrategy(t, updateCommand, repo).Execute(context.Background(), tt.cmd)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.True(t, IsPermanent(err))
+			assert.Equal(t, 60, repo.subs[token].FrequencyMinutes)
+			assert.Empty(t, repo.events)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"subscription-service/internal/repository/subscriptions"
+)
+
+type subscriptionListManager interface {
+	ListByChannelValue(ctx context.Context, channelType, channelValue string) ([]subscriptions.Subscription, error)
+}
+
+type SubscriptionResponse struct {
+	Token            string     `json:"token"`
+	ChannelType      string     `json:"channel_type"`
+	City             string     `json:"city"`
+	FrequencyMinutes int        `json:"frequency_minutes"`
+	DeliveryTime     string     `json:"delivery_time,omitempty"`
+	DeliveryDays     string     `json:"delivery_days,omitempty"`
+	Timezone         string     `json:"timezone"`
+	Confirmed        bool       `json:"confirmed"`
+	PausedAt         *time.Time `json:"paused_at,omitempty"`
+	NextNotifiedAt   time.Time  `json:"next_notified_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+type SubscriptionListResponse struct {
+	Subscriptions []SubscriptionResponse `json:"subscriptions"`
+}
+
+// SubscriptionListHandler serves GET /subscriptions?channel_type=&channel_value=
+// for api-gateway's manage portal. The response includes management tokens,
+// so it is only exposed on the internal port.
+type SubscriptionListHandler struct {
+	repo subscriptionListManager
+}
+
+func NewSubscriptionListHandler(repo subscriptionListManager) *SubscriptionListHandler {
+	return &SubscriptionListHandler{repo: repo}
+}
+
+func (h *SubscriptionListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	channelType := r.URL.Query().Get("channel_type")
+	channelValue := r.URL.Query().Get("channel_value")
+	if channelType == "" || channelValue == "" {
+		http.Error(w, "channel_type and channel_value are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), requestLookupTimeout)
+	defer cancel()
+	subs, err := h.repo.ListByChannelValue(ctx, channelType, channelValue)
+	if err != nil {
+		log.Printf("failed to list subscriptions: %v", err)
+		http.Error(w, "failed to list subscriptions", http.StatusInternalServerError)
+		return
+	}
+
+	resp := SubscriptionListResponse{Subscriptions: make([]SubscriptionResponse, 0, len(subs))}
+	for _, s := range subs {
+		resp.Subscriptions = append(resp.Subscriptions, SubscriptionResponse{
+			Token:            s.Token,
+			ChannelType:      s.ChannelType,
+			City:             s.City,
+			FrequencyMinutes: s.FrequencyMinutes,
+			DeliveryTime:     s.DeliveryTime,
+			DeliveryDays:     s.DeliveryDays,
+			Timezone:         s.Timezone,
+			Confirmed:        s.Confirmed,
+			PausedAt:         s.PausedAt,
+			NextNotifiedAt:   s.NextNotifiedAt,
+			CreatedAt:        s.CreatedAt,
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"subscription-service/internal/domain"
+)
+
+type suppressionRepositoryManager interface {
+	DeleteByChannelValue(ctx context.Context, channelType, channelValue string) (int64, error)
+}
+
+type loggerManager interface {
+	Infof(format string, args ...interface{})
+}
+
+// SuppressionHandler cancels every subscription of a recipient that
+// notification-service suppressed after a hard bounce or spam report. No
+// subscription.cancelled event is emitted: the address can no longer be
+// reached, and mailing a spam reporter again is what suppression prevents.
+type SuppressionHandler struct {
+	repo   suppressionRepositoryManager
+	logger loggerManager
+}
+
+func NewSuppressionHandler(repo suppressionRepositoryManager, logger loggerManager) *SuppressionHandler {
+	return &SuppressionHandler{repo: repo, logger: logger}
+}
+
+func (h *SuppressionHandler) Handle(ctx context.Context, message []byte) error {
+	var event domain.RecipientSuppressedEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal suppression event: %w", err)
+	}
+	if event.ChannelType == "" || event.ChannelValue == "" {
+		return errors.New("suppression event has no recipient")
+	}
+
+	cancelled, err := h.repo.DeleteByChannelValue(ctx, event.ChannelType, event.ChannelValue)
+	if err != nil {
+		return err
+	}
+	h.logger.Infof("cancelled %d %s subscription(s) after %s from %s (event %s)",
+		cancelled, event.ChannelType, event.Reason, event.Source, event.EventID)
+	return nil
+}
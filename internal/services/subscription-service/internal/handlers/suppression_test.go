@@ -0,0 +1,56 @@
+package handlers_test
+
+import (
+	"context"
+	"testing"
+
+	"subscription-service/internal/handlers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Infof(string, ...interface{}) {}
+
+type recordingDeleter struct {
+	calls [][2]string
+}
+
+func (d *recordingDeleter) DeleteByChannelValue(_ context.Context, channelType, channelValue string) (int64, error) {
+	d.calls = append(d.calls, [2]string{channelType, channelValue})
+	return 2, nil
+}
+
+func TestSuppressionHandler_CancelsSubscriptionsOfRecipient(t *testing.T) {
+	repo := &recordingDeleter{}
+	h := handlers.NewSuppressionHandler(repo, nopLogger{})
+
+	err := h.Handle(context.Background(), []byte(`{"event_id":"e1","event_type":"recipient.suppressed",
+		"channel_type":"email","channel_value":"User@Example.com","reason":"bounce","source":"sendgrid"}`))
+
+	require.NoError(t, err)
+	assert.Equal(t, [][2]string{{"email", "User@Example.com"}}, repo.calls)
+}
+
+func TestSuppressionHandler_RejectsInvalidEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+	}{
+		{name: "no recipient", message: `{"event_id":"e1","channel_type":"email","reason":"bounce"}`},
+		{name: "no channel type", message: `{"event_id":"e1","channel_value":"user@example.com","reason":"bounce"}`},
+		{name: "malformed JSON", message: `{"event_id":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &recordingDeleter{}
+
+			err := handlers.NewSuppressionHandler(repo, nopLogger{}).Handle(context.Background(), []byte(tt.message))
+
+			require.Error(t, err)
+			assert.Empty(t, repo.calls)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	HeaderError           = "x-dlq-error"
+	HeaderAttempts        = "x-dlq-attempts"
+	HeaderSourceTopic     = "x-dlq-source-topic"
+	HeaderSourcePartition = "x-dlq-source-partition"
+	HeaderSourceOffset    = "x-dlq-source-offset"
+	HeaderFailedAt        = "x-dlq-failed-at"
+	HeaderReplayedFrom    = "x-dlq-replayed-from"
+
+	dlqReadMaxBytes = 10 * 1024 * 1024
+	dlqReadTimeout  = 10 * time.Second
+)
+
+type DeadLetterTopicResolver func(sourceTopic string) string
+
+// DeadLetterPublisher moves messages that exhausted their retries to a DLQ
+// topic. This file and cmd/dlq-admin have identical copies in notification-service, since
+// each service builds as its own module; keep them in sync.
+type DeadLetterPublisher struct {
+	brokers      []string
+	resolveTopic DeadLetterTopicResolver
+	writers      map[string]messageWriterManager
+	mu           sync.Mutex
+}
+
+func NewDeadLetterPublisher(brokers []string, resolveTopic DeadLetterTopicResolver) *DeadLetterPublisher {
+	return &DeadLetterPublisher{
+		brokers:      brokers,
+		resolveTopic: resolveTopic,
+		writers:      make(map[string]messageWriterManager),
+	}
+}
+
+// PublishDeadLetter forwards the original message to the DLQ topic of its source
+// topic, annotated with the failure reason and source coordinates.
+func (p *DeadLetterPublisher) PublishDeadLetter(ctx context.Context, m kafka.Message, attempts int, cause error) error {
+	dlqTopic := p.resolveTopic(m.Topic)
+	headers := append(stripDeadLetterHeaders(m.Headers),
+		kafka.Header{Key: HeaderError, Value: []byte(cause.Error())},
+		kafka.Header{Key: HeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: HeaderSourceTopic, Value: []byte(m.Topic)},
+		kafka.Header{Key: HeaderSourcePartition, Value: []byte(strconv.Itoa(m.Partition))},
+		kafka.Header{Key: HeaderSourceOffset, Value: []byte(strconv.FormatInt(m.Offset, 10))},
+		kafka.Header{Key: HeaderFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+	)
+	if err := p.getWriter(dlqTopic).WriteMessages(ctx, kafka.Message{
+		Key:     m.Key,
+		Value:   m.Value,
+		Headers: headers,
+	}); err != nil {
+		return fmt.Errorf("failed to publish to dead letter topic %s: %w", dlqTopic, err)
+	}
+	return nil
+}
+
+func (p *DeadLetterPublisher) getWriter(topic string) messageWriterManager {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if w, ok := p.writers[topic]; ok {
+		return w
+	}
+	w := &kafka.Writer{
+		Addr:         kafka.TCP(p.brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.LeastBytes{},
+		RequiredAcks: kafka.RequireAll,
+	}
+	p.writers[topic] = w
+	return w
+}
+
+func (p *DeadLetterPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var firstErr error
+	for _, w := range p.writers {
+		if err := w.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	p.writers = make(map[string]messageWriterManager)
+	return firstErr
+}
+
+// ReadDeadLetters returns up to limit messages currently stored in a DLQ topic,
+// without committing any consumer offsets.
+func ReadDeadLetters(ctx context.Context, broker, topic string, limit int) ([]kafka.Message, error) {
+	conn, err := kafka.DialContext(ctx, "tcp", broker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial broker %s: %w", broker, err)
+	}
+	partitions, err := conn.ReadPartitions(topic)
+	if closeErr := conn.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read partitions for %s: %w", topic, err)
+	}
+
+	var messages []kafka.Message
+	for _, partition := range partitions {
+		if limit > 0 && len(messages) >= limit {
+			break
+		}
+		batch, err := readPartition(ctx, broker, topic, partition.ID, limit-len(messages))
+		if err != nil {
+			return messages, err
+		}
+		messages = append(messages, batch...)
+	}
+	return messages, nil
+}
+
+func readPartition(ctx context.Context, broker, topic string, partition, limit int) ([]kafka.Message, error) {
+	conn, err := kafka.DialLeader(ctx, "tcp", broker, topic, partition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial leader for %s/%d: %w", topic, partition, err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	first, last, err := conn.ReadOffsets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read offsets for %s/%d: %w", topic, partition, err)
+	}
+	if _, err := conn.Seek(first, kafka.SeekAbsolute); err != nil {
+		return nil, fmt.Errorf("failed to seek %s/%d: %w", topic, partition, err)
+	}
+	if err := conn.SetReadDeadline(time.Now().Add(dlqReadTimeout)); err != nil {
+		return nil, err
+	}
+
+	var messages []kafka.Message
+	for offset := first; offset < last; offset++ {
+		if limit > 0 && len(messages) >= limit {
+			break
+		}
+		m, err := conn.ReadMessage(dlqReadMaxBytes)
+		if err != nil {
+			return messages, fmt.Errorf("failed to read %s/%d at offset %d: %w", topic, partition, offset, err)
+		}
+		m.Topic = topic
+		m.Partition = partition
+		messages = append(messages, m)
+	}
+	return messages, nil
+}
+
+// ReplayDeadLetter publishes a DLQ message back to the topic it originally came from.
+func ReplayDeadLetter(ctx context.Context, brokers []string, m kafka.Message) error {
+	sourceTopic, replay, err := replayMessage(m)
+	if err != nil {
+		return err
+	}
+	w := &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        sourceTopic,
+		Balancer:     &kafka.LeastBytes{},
+		RequiredAcks: kafka.RequireAll,
+	}
+	defer func() {
+		_ = w.Close()
+	}()
+
+	if err := w.WriteMessages(ctx, replay); err != nil {
+		return fmt.Errorf("failed to replay message to %s: %w", sourceTopic, err)
+	}
+	return nil
+}
+
+// replayMessage returns the source topic of a DLQ message and the message to
+// publish there: the original payload without the dead letter headers, marked
+// with where it was replayed from.
+func replayMessage(m kafka.Message) (string, kafka.Message, error) {
+	sourceTopic := HeaderValue(m.Headers, HeaderSourceTopic)
+	if sourceTopic == "" {
+		return "", kafka.Message{}, errors.New("dead letter has no source topic header")
+	}
+	headers := append(stripDeadLetterHeaders(m.Headers), kafka.Header{
+		Key:   HeaderReplayedFrom,
+		Value: []byte(fmt.Sprintf("%s/%d/%d", m.Topic, m.Partition, m.Offset)),
+	})
+	return sourceTopic, kafka.Message{Key: m.Key, Value: m.Value, Headers: headers}, nil
+}
+
+func HeaderValue(headers []kafka.Header, key string) string {
+	for _, h := range headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func stripDeadLetterHeaders(headers []kafka.Header) []kafka.Header {
+	out := make([]kafka.Header, 0, len(headers))
+	for _, h := range headers {
+		switch h.Key {
+		case HeaderError, HeaderAttempts, HeaderSourceTopic, HeaderSourcePartition,
+			HeaderSourceOffset, HeaderFailedAt, HeaderReplayedFrom:
+			continue
+		}
+		out = append(out, h)
+	}
+	return out
+}
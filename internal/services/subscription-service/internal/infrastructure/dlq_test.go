@@ -0,0 +1,125 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type recordingWriter struct {
+	messages []kafka.Message
+}
+
+func (w *recordingWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+func (w *recordingWriter) Close() error {
+	return nil
+}
+
+func TestPublishDeadLetter_AnnotatesOriginalMessage(t *testing.T) {
+	writer := &recordingWriter{}
+	p := NewDeadLetterPublisher([]string{"localhost:9092"}, func(topic string) string { return topic + ".dlq" })
+	p.writers["commands.subscription.dlq"] = writer
+
+	original := kafka.Message{
+		Topic:     "commands.subscription",
+		Partition: 2,
+		Offset:    41,
+		Key:       []byte("key"),
+		Value:     []byte(`{"command":"pause"}`),
+		Headers: []kafka.Header{
+			{Key: "trace-id", Value: []byte("abc")},
+			{Key: HeaderError, Value: []byte("error of an earlier failure")},
+		},
+	}
+	before := time.Now().UTC().Truncate(time.Second)
+	if err := p.PublishDeadLetter(context.Background(), original, 3, errors.New("boom")); err != nil {
+		t.Fatalf("PublishDeadLetter: %v", err)
+	}
+
+	if len(writer.messages) != 1 {
+		t.Fatalf("wrote %d messages, want 1", len(writer.messages))
+	}
+	m := writer.messages[0]
+	if string(m.Key) != "key" || string(m.Value) != `{"command":"pause"}` {
+		t.Errorf("message = %s/%s, want the original key and payload", m.Key, m.Value)
+	}
+	want := map[string]string{
+		"trace-id":            "abc",
+		HeaderError:           "boom",
+		HeaderAttempts:        "3",
+		HeaderSourceTopic:     "commands.subscription",
+		HeaderSourcePartition: "2",
+		HeaderSourceOffset:    "41",
+	}
+	for key, value := range want {
+		if got := HeaderValue(m.Headers, key); got != value {
+			t.Errorf("header %s = %q, want %q", key, got, value)
+		}
+	}
+	if len(m.Headers) != len(want)+1 {
+		t.Errorf("headers = %v, want each header once", m.Headers)
+	}
+	failedAt, err := time.Parse(time.RFC3339, HeaderValue(m.Headers, HeaderFailedAt))
+	if err != nil {
+		t.Fatalf("parse %s: %v", HeaderFailedAt, err)
+	}
+	if failedAt.Before(before) || failedAt.After(time.Now().Add(time.Second)) {
+		t.Errorf("%s = %v, want about now", HeaderFailedAt, failedAt)
+	}
+}
+
+func TestReplayMessage_StripsDeadLetterHeaders(t *testing.T) {
+	dead := kafka.Message{
+		Topic:     "commands.subscription.dlq",
+		Partition: 0,
+		Offset:    7,
+		Key:       []byte("key"),
+		Value:     []byte(`{"command":"pause"}`),
+		Headers: []kafka.Header{
+			{Key: "trace-id", Value: []byte("abc")},
+			{Key: HeaderError, Value: []byte("boom")},
+			{Key: HeaderAttempts, Value: []byte("3")},
+			{Key: HeaderSourceTopic, Value: []byte("commands.subscription")},
+			{Key: HeaderSourcePartition, Value: []byte("2")},
+			{Key: HeaderSourceOffset, Value: []byte("41")},
+			{Key: HeaderFailedAt, Value: []byte("2025-06-10T12:00:00Z")},
+			{Key: HeaderReplayedFrom, Value: []byte("commands.subscription.dlq/0/3")},
+		},
+	}
+
+	topic, replay, err := replayMessage(dead)
+	if err != nil {
+		t.Fatalf("replayMessage: %v", err)
+	}
+	if topic != "commands.subscription" {
+		t.Errorf("topic = %q, want commands.subscription", topic)
+	}
+	if string(replay.Key) != "key" || string(replay.Value) != `{"command":"pause"}` {
+		t.Errorf("message = %s/%s, want the original key and payload", replay.Key, replay.Value)
+	}
+	want := []kafka.Header{
+		{Key: "trace-id", Value: []byte("abc")},
+		{Key: HeaderReplayedFrom, Value: []byte("commands.subscription.dlq/0/7")},
+	}
+	if len(replay.Headers) != len(want) {
+		t.Fatalf("headers = %v, want %v", replay.Headers, want)
+	}
+	for i, h := range want {
+		if replay.Headers[i].Key != h.Key || string(replay.Headers[i].Value) != string(h.Value) {
+			t.Errorf("header %d = %s: %s, want %s: %s", i, replay.Headers[i].Key, replay.Headers[i].Value, h.Key, h.Value)
+		}
+	}
+}
+
+func TestReplayMessage_RequiresSourceTopic(t *testing.T) {
+	if _, _, err := replayMessage(kafka.Message{Value: []byte("{}")}); err == nil {
+		t.Fatal("expected an error for a message without a source topic header")
+	}
+}
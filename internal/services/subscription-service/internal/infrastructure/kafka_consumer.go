@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -30,12 +31,32 @@ type loggerManager interface {
 
 type StrategySelector func(cmd string) (subscribestrategies.CommandStrategy, error)
 
+// EventHandler processes a message from a topic registered with HandleTopic
+// instead of the subscription command strategies.
+type EventHandler func(ctx context.Context, message []byte) error
+
+type deadLetterPublisherManager interface {
+	PublishDeadLetter(ctx context.Context, m kafka.Message, attempts int, cause error) error
+}
+
+type commandOutcomeRecorderManager interface {
+	MarkPending(ctx context.Context, requestID, command string) error
+	MarkSucceeded(ctx context.Context, requestID string) error
+	MarkFailed(ctx context.Context, requestID, reason string) error
+}
+
 type KafkaConsumer struct {
 	brokers   []string
 	topics    []string
 	groupID   string
 	logger    loggerManager
 	selectStrategy StrategySelector
+	deadLetters    deadLetterPublisherManager
+	outcomes       commandOutcomeRecorderManager
+	eventHandlers  map[string]EventHandler
+	// retryDelay is the first pause between handler attempts; it doubles
+	// after every failed attempt.
+	retryDelay time.Duration
 }
 
 func NewKafkaConsumer(
@@ -43,6 +64,8 @@ func NewKafkaConsumer(
 	groupID string,
 	logger loggerManager,
 	selector StrategySelector,
+	deadLetters deadLetterPublisherManager,
+	outcomes commandOutcomeRecorderManager,
 ) *KafkaConsumer {
 	return &KafkaConsumer{
 		brokers:   brokers,
@@ -50,7 +73,20 @@ func NewKafkaConsumer(
 		groupID:   groupID,
 		logger:    logger,
 		selectStrategy: selector,
+		deadLetters:    deadLetters,
+		outcomes:       outcomes,
+		eventHandlers:  map[string]EventHandler{},
+		retryDelay:     delay,
+	}
+}
+
+// HandleTopic additionally consumes topic, passing its messages to handler
+// with the same retries and dead-lettering as commands. Call it before Start.
+func (c *KafkaConsumer) HandleTopic(topic string, handler EventHandler) {
+	if _, ok := c.eventHandlers[topic]; !ok {
+		c.topics = append(c.topics, topic)
 	}
+	c.eventHandlers[topic] = handler
 }
 
 func (c *KafkaConsumer) Start(ctx context.Context) <-chan struct{} {
@@ -125,11 +161,28 @@ func (c *KafkaConsumer) consumeTopic(ctx context.Context, topic string) error {
 
 		c.logger.Infof("received event from topic %s, partition %d, offset %d", topic, m.Partition, m.Offset)
 
-		if err := c.processWithRetry(ctx, topic, m.Value); err != nil {
-			c.logger.Infof("handler failed for topic %s: %v", topic, err)
+		if handler, ok := c.eventHandlers[topic]; ok {
+			attempts, err := c.handleWithRetry(ctx, topic, handler, m.Value)
+			if err != nil && ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != nil {
+				c.logger.Errorf("handler failed for topic %s, moving offset %d to dead letter topic: %v", topic, m.Offset, err)
+				if err := c.deadLetters.PublishDeadLetter(ctx, m, attempts, err); err != nil {
+					c.logger.Errorf("failed to dead-letter message for topic %s: %v", topic, err)
+					return err
+				}
+			}
+			if err := r.CommitMessages(ctx, m); err != nil {
+				c.logger.Errorf("failed to commit message for topic %s: %v", topic, err)
+				return err
+			}
 			continue
 		}
 
+		if err := c.handleCommand(ctx, topic, m); err != nil {
+			return err
+		}
 		if err := r.CommitMessages(ctx, m); err != nil {
 			c.logger.Errorf("failed to commit message for topic %s: %v", topic, err)
 			return err
@@ -137,41 +190,138 @@ func (c *KafkaConsumer) consumeTopic(ctx context.Context, topic string) error {
 	}
 }
 
-func (c *KafkaConsumer) processWithRetry(ctx context.Context, topic string, msg []byte) error {
-	retryDelay := delay
+// handleCommand applies a subscription command and records its request
+// outcome. Commands rejected because of what was requested, such as an unknown
+// token, are only recorded as failed; other failures are dead-lettered. A
+// non-nil error means the message must not be committed.
+func (c *KafkaConsumer) handleCommand(ctx context.Context, topic string, m kafka.Message) error {
+	requestID, command := commandRequestOf(m.Value)
+	if requestID != "" {
+		if err := c.outcomes.MarkPending(ctx, requestID, command); err != nil {
+			c.logger.Errorf("failed to record pending request %s: %v", requestID, err)
+		}
+	}
+
+	attempts, err := c.processWithRetry(ctx, topic, m.Value)
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	c.recordOutcome(ctx, requestID, err)
+	if err == nil {
+		return nil
+	}
+	if subscribestrategies.IsRejected(err) {
+		c.logger.Infof("command at offset %d of topic %s rejected: %v", m.Offset, topic, err)
+		return nil
+	}
+	c.logger.Errorf("handler failed for topic %s, moving offset %d to dead letter topic: %v", topic, m.Offset, err)
+	if err := c.deadLetters.PublishDeadLetter(ctx, m, attempts, err); err != nil {
+		c.logger.Errorf("failed to dead-letter message for topic %s: %v", topic, err)
+		return err
+	}
+	return nil
+}
+
+func (c *KafkaConsumer) processWithRetry(ctx context.Context, topic string, msg []byte) (int, error) {
+	retryDelay := c.retryDelay
 	var lastErr error
+	attempts := 0
+
+	// Malformed messages and unknown commands fail the same way every time, so
+	// they are reported as they are instead of being retried.
+	var cmd domain.SubscriptionCommand
+	if err := json.Unmarshal(msg, &cmd); err != nil {
+		c.logger.Errorf("failed to unmarshal message for topic %s: %v", topic, err)
+		return 1, fmt.Errorf("failed to unmarshal command: %w", err)
+	}
+	strategy, err := c.selectStrategy(cmd.Command)
+	if err != nil {
+		c.logger.Errorf("failed to get strategy for command %s: %v", cmd.Command, err)
+		return 1, err
+	}
 
 	for i := 0; i < maxHandlerRetryAttempts; i++ {
-		var cmd domain.SubscriptionCommand
-		if err := json.Unmarshal(msg, &cmd); err != nil {
-			c.logger.Errorf("failed to unmarshal message for topic %s: %v", topic, err)
-			lastErr = err
+		attempts = i + 1
+		err := strategy.Execute(ctx, cmd)
+		if err == nil {
+			return attempts, nil
+		}
+		if subscribestrategies.IsPermanent(err) {
+			// Deterministic failures such as an unknown token or an invalid
+			// schedule recur on every attempt, and so does the recorded outcome
+			// of a replayed command, so they are not retried.
+			c.logger.Errorf("strategy execution failed permanently for topic %s: %v", topic, err)
+			return attempts, err
+		}
+		lastErr = err
+		c.logger.Errorf("strategy execution error (attempt %d/%d) for topic %s: %v", i+1, maxHandlerRetryAttempts, topic, err)
+		if attempts == maxHandlerRetryAttempts {
 			break
 		}
 
-		strategy, err := c.selectStrategy(cmd.Command)
-		if err != nil {
-			c.logger.Errorf("failed to get strategy for command %s: %v", cmd.Command, err)
-			lastErr = err
-			break
+		select {
+		case <-time.After(retryDelay):
+			retryDelay *= 2
+		case <-ctx.Done():
+			return attempts, ctx.Err()
 		}
+	}
+	return attempts, fmt.Errorf("max handler retry attempts reached: %w", lastErr)
+}
 
-		err = strategy.Execute(ctx, cmd)
-		if err == nil {
-			return nil
+func (c *KafkaConsumer) handleWithRetry(ctx context.Context, topic string, handler EventHandler, msg []byte) (int, error) {
+	retryDelay := c.retryDelay
+	var lastErr error
+
+	for i := 0; i < maxHandlerRetryAttempts; i++ {
+		lastErr = handler(ctx, msg)
+		if lastErr == nil {
+			return i + 1, nil
+		}
+		c.logger.Errorf("event handler error (attempt %d/%d) for topic %s: %v", i+1, maxHandlerRetryAttempts, topic, lastErr)
+		if i+1 == maxHandlerRetryAttempts {
+			break
 		}
-		lastErr = err
-		c.logger.Errorf("strategy execution error (attempt %d/%d) for topic %s: %v", i+1, maxHandlerRetryAttempts, topic, err)
 
 		select {
 		case <-time.After(retryDelay):
 			retryDelay *= 2
 		case <-ctx.Done():
-			return ctx.Err()
+			return i + 1, ctx.Err()
 		}
 	}
-	if lastErr == nil {
-		lastErr = errors.New("unknown error")
+	return maxHandlerRetryAttempts, fmt.Errorf("max handler retry attempts reached: %w", lastErr)
+}
+
+func (c *KafkaConsumer) recordOutcome(ctx context.Context, requestID string, err error) {
+	if requestID == "" {
+		return
+	}
+	var recordErr error
+	if err == nil {
+		recordErr = c.outcomes.MarkSucceeded(ctx, requestID)
+	} else {
+		recordErr = c.outcomes.MarkFailed(ctx, requestID, rootCause(err).Error())
+	}
+	if recordErr != nil {
+		c.logger.Errorf("failed to record outcome for request %s: %v", requestID, recordErr)
+	}
+}
+
+func commandRequestOf(msg []byte) (string, string) {
+	var cmd domain.SubscriptionCommand
+	if err := json.Unmarshal(msg, &cmd); err != nil {
+		return "", ""
+	}
+	return cmd.RequestID, cmd.Command
+}
+
+func rootCause(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
 	}
-	return errors.New("max handler retry attempts reached: " + lastErr.Error())
 }
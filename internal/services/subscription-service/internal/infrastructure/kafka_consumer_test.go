@@ -0,0 +1,211 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"subscription-service/internal/domain"
+	subscribestrategies "subscription-service/internal/handlers/subscribe-strategies"
+	"subscription-service/internal/repository/subscriptions"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Infof(string, ...interface{})  {}
+func (nopLogger) Errorf(string, ...interface{}) {}
+func (nopLogger) Debugf(string, ...interface{}) {}
+
+type strategyFunc func(ctx context.Context, cmd domain.SubscriptionCommand) error
+
+func (f strategyFunc) Execute(ctx context.Context, cmd domain.SubscriptionCommand) error {
+	return f(ctx, cmd)
+}
+
+type recordingDeadLetters struct {
+	causes   []error
+	attempts []int
+}
+
+func (d *recordingDeadLetters) PublishDeadLetter(_ context.Context, _ kafka.Message, attempts int, cause error) error {
+	d.causes = append(d.causes, cause)
+	d.attempts = append(d.attempts, attempts)
+	return nil
+}
+
+type recordingOutcomes struct {
+	failed    map[string]string
+	succeeded []string
+}
+
+func (o *recordingOutcomes) MarkPending(context.Context, string, string) error { return nil }
+
+func (o *recordingOutcomes) MarkSucceeded(_ context.Context, requestID string) error {
+	o.succeeded = append(o.succeeded, requestID)
+	return nil
+}
+
+func (o *recordingOutcomes) MarkFailed(_ context.Context, requestID, reason string) error {
+	o.failed[requestID] = reason
+	return nil
+}
+
+func newTestConsumer(strategy subscribestrategies.CommandStrategy) (*KafkaConsumer, *recordingDeadLetters, *recordingOutcomes) {
+	deadLetters := &recordingDeadLetters{}
+	outcomes := &recordingOutcomes{failed: map[string]string{}}
+	selector := func(cmd string) (subscribestrategies.CommandStrategy, error) {
+		if cmd != "pause" {
+			return nil, errors.New("unknown command: " + cmd)
+		}
+		return strategy, nil
+	}
+	c := NewKafkaConsumer(nil, nil, "test", nopLogger{}, selector, deadLetters, outcomes)
+	return c, deadLetters, outcomes
+}
+
+func commandMessage(value string) kafka.Message {
+	return kafka.Message{Topic: "commands.subscription", Value: []byte(value)}
+}
+
+func TestHandleCommand_DeadLettersUnprocessableMessages(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		err       error
+		wantCause string
+	}{
+		{name: "malformed JSON", value: `{"command":`, wantCause: "failed to unmarshal command"},
+		{name: "unknown command", value: `{"command":"rewind","request_id":"req-1"}`, wantCause: "unknown command: rewind"},
+		{
+			name:      "invalid command",
+			value:     `{"command":"pause","request_id":"req-1"}`,
+			err:       fmt.Errorf("%w: bad schedule", subscribestrategies.ErrInvalidCommand),
+			wantCause: "bad schedule",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			c, deadLetters, _ := newTestConsumer(strategyFunc(func(context.Context, domain.SubscriptionCommand) error {
+				calls++
+				return tt.err
+			}))
+
+			require.NoError(t, c.handleCommand(context.Background(), "commands.subscription", commandMessage(tt.value)))
+
+			require.Len(t, deadLetters.causes, 1)
+			assert.Contains(t, deadLetters.causes[0].Error(), tt.wantCause)
+			assert.NotContains(t, deadLetters.causes[0].Error(), "max handler retry attempts reached")
+			assert.Equal(t, []int{1}, deadLetters.attempts)
+			assert.LessOrEqual(t, calls, 1)
+		})
+	}
+}
+
+func TestHandleCommand_CommitsRejectedCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "unknown token", err: subscriptions.ErrSubscriptionNotFound},
+		{name: "expired token", err: subscriptions.ErrTokenExpired},
+		{name: "already subscribed", err: subscriptions.ErrAlreadySubscribed},
+		{name: "already paused", err: subscriptions.ErrAlreadyPaused},
+		{name: "replayed rejection", err: &domain.ReplayedFailureError{Reason: "subscription not found", Rejected: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			c, deadLetters, outcomes := newTestConsumer(strategyFunc(func(context.Context, domain.SubscriptionCommand) error {
+				calls++
+				return tt.err
+			}))
+
+			err := c.handleCommand(context.Background(), "commands.subscription",
+				commandMessage(`{"command":"pause","request_id":"req-1"}`))
+			require.NoError(t, err)
+
+			assert.Equal(t, 1, calls, "rejected commands are not retried")
+			assert.Empty(t, deadLetters.causes)
+			assert.Contains(t, outcomes.failed, "req-1")
+		})
+	}
+}
+
+func TestHandleCommand_DeadLettersReplayedUnprocessableCommand(t *testing.T) {
+	c, deadLetters, _ := newTestConsumer(strategyFunc(func(context.Context, domain.SubscriptionCommand) error {
+		return &domain.ReplayedFailureError{Reason: "invalid command: bad schedule"}
+	}))
+
+	require.NoError(t, c.handleCommand(context.Background(), "commands.subscription",
+		commandMessage(`{"command":"pause"}`)))
+	assert.Len(t, deadLetters.causes, 1)
+}
+
+func TestHandleCommand_RetriesTransientFailures(t *testing.T) {
+	calls := 0
+	c, deadLetters, outcomes := newTestConsumer(strategyFunc(func(context.Context, domain.SubscriptionCommand) error {
+		calls++
+		if calls == 1 {
+			return errors.New("connection reset")
+		}
+		return nil
+	}))
+
+	require.NoError(t, c.handleCommand(context.Background(), "commands.subscription",
+		commandMessage(`{"command":"pause","request_id":"req-1"}`)))
+
+	assert.Equal(t, 2, calls)
+	assert.Empty(t, deadLetters.causes)
+	assert.Equal(t, []string{"req-1"}, outcomes.succeeded)
+}
+
+func TestHandleWithRetry_DoesNotWaitAfterLastAttempt(t *testing.T) {
+	c, _, _ := newTestConsumer(nil)
+	c.retryDelay = time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handlerErr := errors.New("database unavailable")
+	calls := 0
+	attempts, err := c.handleWithRetry(ctx, "recipient.suppressed", func(context.Context, []byte) error {
+		calls++
+		if calls == maxHandlerRetryAttempts {
+			// A wait after this attempt would see the cancelled context.
+			cancel()
+		}
+		return handlerErr
+	}, nil)
+
+	assert.Equal(t, maxHandlerRetryAttempts, calls)
+	assert.Equal(t, maxHandlerRetryAttempts, attempts)
+	require.ErrorIs(t, err, handlerErr)
+	assert.Contains(t, err.Error(), "max handler retry attempts reached")
+}
+
+func TestProcessWithRetry_DoesNotWaitAfterLastAttempt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	strategyErr := errors.New("database unavailable")
+	calls := 0
+	c, _, _ := newTestConsumer(strategyFunc(func(context.Context, domain.SubscriptionCommand) error {
+		calls++
+		if calls == maxHandlerRetryAttempts {
+			cancel()
+		}
+		return strategyErr
+	}))
+	c.retryDelay = time.Millisecond
+
+	attempts, err := c.processWithRetry(ctx, "commands.subscription", []byte(`{"command":"pause"}`))
+
+	assert.Equal(t, maxHandlerRetryAttempts, attempts)
+	require.ErrorIs(t, err, strategyErr)
+}
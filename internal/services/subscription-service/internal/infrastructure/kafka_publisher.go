@@ -36,7 +36,9 @@ func (p *KafkaPublisher) getWriter(topic string) messageWriterManager {
     w := &kafka.Writer{
 		Addr:     kafka.TCP(p.brokers...),
 		Topic:    topic,
-		Balancer: &kafka.LeastBytes{},
+		// Hash keeps messages with the same key on one partition, in order;
+		// unkeyed messages are spread round-robin.
+		Balancer: &kafka.Hash{},
 	}
 	p.writers[topic] = w
 	return w
@@ -51,6 +53,12 @@ func (p *KafkaPublisher) PublishWithTopic(ctx context.Context, topic string, eve
     return writer.WriteMessages(ctx, kafka.Message{Value: msg})
 }
 
+// PublishMessage writes an already encoded message, used by the outbox relay.
+func (p *KafkaPublisher) PublishMessage(ctx context.Context, topic string, key, value []byte) error {
+	writer := p.getWriter(topic)
+	return writer.WriteMessages(ctx, kafka.Message{Key: key, Value: value})
+}
+
 func (p *KafkaPublisher) Publish(ctx context.Context, event interface{}) error {
 	return p.PublishWithTopic(ctx, "default", event)
 }
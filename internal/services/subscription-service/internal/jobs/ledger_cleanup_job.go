@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"context"
+	"time"
+)
+
+type expiredCommandsDeleterManager interface {
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// LedgerCleanupJob deletes processed command IDs whose replay window expired.
+type LedgerCleanupJob struct {
+	repo     expiredCommandsDeleterManager
+	logger   loggerManager
+	interval time.Duration
+}
+
+func NewLedgerCleanupJob(
+	repo expiredCommandsDeleterManager,
+	logger loggerManager,
+	interval time.Duration,
+) *LedgerCleanupJob {
+	return &LedgerCleanupJob{
+		repo:     repo,
+		logger:   logger,
+		interval: interval,
+	}
+}
+
+func (j *LedgerCleanupJob) Run(ctx context.Context) {
+	deleted, err := j.repo.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		j.logger.Errorf("failed to delete expired processed commands: %v", err)
+		return
+	}
+	if deleted > 0 {
+		j.logger.Infof("deleted %d expired processed commands", deleted)
+	}
+}
+
+func (j *LedgerCleanupJob) StartPeriodic(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.Run(ctx)
+		case <-ctx.Done():
+			j.logger.Infof("LedgerCleanupJob stopped")
+			return
+		}
+	}
+}
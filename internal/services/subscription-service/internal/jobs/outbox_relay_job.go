@@ -0,0 +1,102 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"subscription-service/internal/observability/metrics"
+	"subscription-service/internal/repository/outbox"
+)
+
+type outboxRepositoryManager interface {
+	RelayBatch(ctx context.Context, limit int, publish func(outbox.Message) error) (int, error)
+	PendingStats(ctx context.Context) (outbox.PendingStats, error)
+	DeleteSentBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type messagePublisherManager interface {
+	PublishMessage(ctx context.Context, topic string, key, value []byte) error
+}
+
+type OutboxRelayJobConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Retention    time.Duration
+}
+
+// OutboxRelayJob publishes outbox rows to Kafka in insertion order and
+// marks them sent, exporting the backlog size and age as lag metrics.
+type OutboxRelayJob struct {
+	repo      outboxRepositoryManager
+	publisher messagePublisherManager
+	logger    loggerManager
+	cfg       OutboxRelayJobConfig
+}
+
+func NewOutboxRelayJob(
+	repo outboxRepositoryManager,
+	publisher messagePublisherManager,
+	logger loggerManager,
+	cfg OutboxRelayJobConfig,
+) *OutboxRelayJob {
+	return &OutboxRelayJob{
+		repo:      repo,
+		publisher: publisher,
+		logger:    logger,
+		cfg:       cfg,
+	}
+}
+
+func (j *OutboxRelayJob) Run(ctx context.Context) {
+	for {
+		sent, err := j.repo.RelayBatch(ctx, j.cfg.BatchSize, func(m outbox.Message) error {
+			var key []byte
+			if m.Key != "" {
+				key = []byte(m.Key)
+			}
+			return j.publisher.PublishMessage(ctx, m.Topic, key, m.Payload)
+		})
+		metrics.OutboxPublished.Add(float64(sent))
+		if err != nil {
+			metrics.OutboxPublishErrors.Inc()
+			j.logger.Errorf("outbox relay failed after %d messages: %v", sent, err)
+			break
+		}
+		if sent < j.cfg.BatchSize {
+			break
+		}
+	}
+
+	j.updateLag(ctx)
+
+	if deleted, err := j.repo.DeleteSentBefore(ctx, time.Now().Add(-j.cfg.Retention)); err != nil {
+		j.logger.Errorf("failed to clean up outbox: %v", err)
+	} else if deleted > 0 {
+		j.logger.Debugf("deleted %d sent outbox messages", deleted)
+	}
+}
+
+func (j *OutboxRelayJob) updateLag(ctx context.Context) {
+	stats, err := j.repo.PendingStats(ctx)
+	if err != nil {
+		j.logger.Errorf("failed to get outbox stats: %v", err)
+		return
+	}
+	metrics.OutboxPending.Set(float64(stats.Count))
+	metrics.OutboxLagSeconds.Set(stats.OldestAge.Seconds())
+}
+
+func (j *OutboxRelayJob) StartPeriodic(ctx context.Context) {
+	ticker := time.NewTicker(j.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.Run(ctx)
+		case <-ctx.Done():
+			j.logger.Infof("OutboxRelayJob stopped")
+			return
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"subscription-service/internal/jobs"
+	"subscription-service/internal/repository/outbox"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Infof(string, ...interface{})  {}
+func (nopLogger) Errorf(string, ...interface{}) {}
+func (nopLogger) Debugf(string, ...interface{}) {}
+
+// fakeOutbox hands out pending messages in batches the way RelayBatch does,
+// keeping those whose publication failed for the next run.
+type fakeOutbox struct {
+	pending   []outbox.Message
+	batches   int
+	deletedAt time.Time
+}
+
+func (f *fakeOutbox) RelayBatch(_ context.Context, limit int, publish func(outbox.Message) error) (int, error) {
+	f.batches++
+	sent := 0
+	for sent < limit && sent < len(f.pending) {
+		if err := publish(f.pending[sent]); err != nil {
+			f.pending = f.pending[sent:]
+			return sent, err
+		}
+		sent++
+	}
+	f.pending = f.pending[sent:]
+	return sent, nil
+}
+
+func (f *fakeOutbox) PendingStats(context.Context) (outbox.PendingStats, error) {
+	return outbox.PendingStats{Count: int64(len(f.pending))}, nil
+}
+
+func (f *fakeOutbox) DeleteSentBefore(_ context.Context, before time.Time) (int64, error) {
+	f.deletedAt = before
+	return 0, nil
+}
+
+type fakePublisher struct {
+	published []string
+	failOn    string
+}
+
+func (p *fakePublisher) PublishMessage(_ context.Context, topic string, key, _ []byte) error {
+	if string(key) == p.failOn {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, topic+"/"+string(key))
+	return nil
+}
+
+func messages(keys ...string) []outbox.Message {
+	result := make([]outbox.Message, len(keys))
+	for i, k := range keys {
+		result[i] = outbox.Message{ID: int64(i + 1), Topic: "events", Key: k}
+	}
+	return result
+}
+
+func newOutboxRelayJob(repo *fakeOutbox, publisher *fakePublisher) *jobs.OutboxRelayJob {
+	return jobs.NewOutboxRelayJob(repo, publisher, nopLogger{}, jobs.OutboxRelayJobConfig{
+		BatchSize: 2,
+		Retention: time.Hour,
+	})
+}
+
+func TestOutboxRelayJob_DrainsFullBatches(t *testing.T) {
+	repo := &fakeOutbox{pending: messages("a", "b", "c")}
+	publisher := &fakePublisher{}
+
+	start := time.Now()
+	newOutboxRelayJob(repo, publisher).Run(context.Background())
+
+	assert.Equal(t, []string{"events/a", "events/b", "events/c"}, publisher.published)
+	assert.Equal(t, 2, repo.batches)
+	assert.Empty(t, repo.pending)
+	assert.WithinDuration(t, start.Add(-time.Hour), repo.deletedAt, time.Minute)
+}
+
+func TestOutboxRelayJob_StopsOnPublishError(t *testing.T) {
+	repo := &fakeOutbox{pending: messages("a", "b", "c", "d")}
+	publisher := &fakePublisher{failOn: "b"}
+
+	newOutboxRelayJob(repo, publisher).Run(context.Background())
+
+	assert.Equal(t, []string{"events/a"}, publisher.published)
+	assert.Equal(t, 1, repo.batches, "a failed batch must not be followed by another in the same run")
+	assert.Len(t, repo.pending, 3)
+}
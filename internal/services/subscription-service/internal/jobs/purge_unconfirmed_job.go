@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"time"
+)
+
+type unconfirmedPurgerManager interface {
+	PurgeExpiredUnconfirmed(ctx context.Context, now time.Time) (int64, error)
+}
+
+// PurgeUnconfirmedJob deletes subscriptions whose confirmation token expired
+// before they were confirmed, freeing the channel/city pair for a new subscribe.
+type PurgeUnconfirmedJob struct {
+	repo     unconfirmedPurgerManager
+	logger   loggerManager
+	interval time.Duration
+}
+
+func NewPurgeUnconfirmedJob(
+	repo unconfirmedPurgerManager,
+	logger loggerManager,
+	interval time.Duration,
+) *PurgeUnconfirmedJob {
+	return &PurgeUnconfirmedJob{
+		repo:     repo,
+		logger:   logger,
+		interval: interval,
+	}
+}
+
+func (j *PurgeUnconfirmedJob) Run(ctx context.Context) {
+	purged, err := j.repo.PurgeExpiredUnconfirmed(ctx, time.Now())
+	if err != nil {
+		j.logger.Errorf("failed to purge unconfirmed subscriptions: %v", err)
+		return
+	}
+	if purged > 0 {
+		j.logger.Infof("purged %d unconfirmed subscriptions with expired tokens", purged)
+	}
+}
+
+func (j *PurgeUnconfirmedJob) StartPeriodic(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.Run(ctx)
+		case <-ctx.Done():
+			j.logger.Infof("PurgeUnconfirmedJob stopped")
+			return
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"subscription-service/internal/jobs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePurger struct {
+	calls []time.Time
+}
+
+func (p *fakePurger) PurgeExpiredUnconfirmed(_ context.Context, now time.Time) (int64, error) {
+	p.calls = append(p.calls, now)
+	return 2, nil
+}
+
+func TestPurgeUnconfirmedJob_PurgesTokensExpiredByNow(t *testing.T) {
+	purger := &fakePurger{}
+	before := time.Now()
+
+	jobs.NewPurgeUnconfirmedJob(purger, nopLogger{}, time.Hour).Run(context.Background())
+
+	if assert.Len(t, purger.calls, 1) {
+		assert.WithinRange(t, purger.calls[0], before, time.Now())
+	}
+}
@@ -2,13 +2,21 @@ package jobs
 
 import (
 	"context"
+	"strings"
+	"sync"
 	"time"
 
 	"subscription-service/internal/domain"
-	"subscription-service/internal/repository/subscriptions"
+	"subscription-service/internal/observability/metrics"
 	"subscription-service/internal/proto"
+	"subscription-service/internal/repository/subscriptions"
+	"subscription-service/internal/schedule"
+
+	"github.com/google/uuid"
 )
 
+const defaultFetchConcurrency = 1
+
 type subscriptionRepositoryManager interface {
 	GetDueSubscriptions(ctx context.Context) ([]subscriptions.Subscription, error)
 	UpdateNextNotification(ctx context.Context, subscriptionID int64, t time.Time) error
@@ -28,11 +36,18 @@ type loggerManager interface {
 	Debugf(format string, args ...interface{})
 }
 
+type WeatherUpdateJobConfig struct {
+	CatchUpPolicy       schedule.CatchUpPolicy
+	MissedSlotTolerance time.Duration
+	FetchConcurrency    int
+}
+
 type WeatherUpdateJob struct {
 	repo          subscriptionRepositoryManager
 	publisher     eventPublisherManager
 	weatherClient weatherClientManager
-	logger loggerManager
+	logger        loggerManager
+	cfg           WeatherUpdateJobConfig
 }
 
 func NewWeatherUpdateJob(
@@ -40,12 +55,17 @@ func NewWeatherUpdateJob(
 	publisher eventPublisherManager,
 	weatherClient weatherClientManager,
 	logger loggerManager,
+	cfg WeatherUpdateJobConfig,
 ) *WeatherUpdateJob {
+	if cfg.FetchConcurrency <= 0 {
+		cfg.FetchConcurrency = defaultFetchConcurrency
+	}
 	return &WeatherUpdateJob{
 		repo:          repo,
 		publisher:     publisher,
 		weatherClient: weatherClient,
-		logger: logger,
+		logger:        logger,
+		cfg:           cfg,
 	}
 }
 
@@ -55,30 +75,123 @@ func (j *WeatherUpdateJob) Run(ctx context.Context) {
 		j.logger.Errorf("failed to get due subscriptions: %v", err)
 		return
 	}
-	for _, s := range subscriptions {
-		weatherResp, err := j.weatherClient.GetWeather(ctx, &proto.WeatherRequest{City: s.City})
-		if err != nil {
-			j.logger.Errorf("failed to get weather for city=%s: %v", s.City, err)
+
+	now := time.Now()
+	byCity := j.groupByCity(ctx, subscriptions, now)
+	weather := j.fetchWeather(ctx, byCity)
+
+	for city, subs := range byCity {
+		weatherResp, ok := weather[city]
+		if !ok {
 			continue
 		}
-		event := domain.WeatherUpdateEvent{
-			Email:       s.ChannelValue,
-			Metrics: domain.WeatherMetrics{
-				City:        s.City,
-				Description: weatherResp.Description,
-				Temperature: weatherResp.Temperature,
-				Humidity:    weatherResp.Humidity,
-			},
-			UpdatedAt: time.Now().Unix(),
+		for _, s := range subs {
+			if j.notify(ctx, s, weatherResp) {
+				metrics.WeatherJobSubscriptionsServed.Inc()
+			}
+			j.reschedule(ctx, s, now)
 		}
+	}
+}
 
-		if err := j.publisher.PublishWithTopic(ctx, "weather.updated", event); err != nil {
-			j.logger.Errorf("failed to publish weather update for user=%d: %v", s.ID, err)
+// groupByCity buckets due subscriptions by normalized city name, rescheduling
+// the ones whose slot is dropped by the catch-up policy.
+func (j *WeatherUpdateJob) groupByCity(
+	ctx context.Context,
+	subs []subscriptions.Subscription,
+	now time.Time,
+) map[string][]subscriptions.Subscription {
+	byCity := make(map[string][]subscriptions.Subscription)
+	for _, s := range subs {
+		if !schedule.ShouldSend(j.cfg.CatchUpPolicy, s.NextNotifiedAt, now, j.cfg.MissedSlotTolerance) {
+			j.logger.Infof("skipping missed slot %s for user=%d", s.NextNotifiedAt.Format(time.RFC3339), s.ID)
+			j.reschedule(ctx, s, now)
+			continue
 		}
+		key := normalizeCity(s.City)
+		byCity[key] = append(byCity[key], s)
+	}
+	return byCity
+}
 
-		if err := j.repo.UpdateNextNotification(ctx, int64(s.ID), time.Now()); err != nil {
-			j.logger.Errorf("failed to update next notification for user=%d: %v", s.ID, err)
-		}
+// fetchWeather requests each city once, running at most FetchConcurrency calls in parallel.
+func (j *WeatherUpdateJob) fetchWeather(
+	ctx context.Context,
+	byCity map[string][]subscriptions.Subscription,
+) map[string]*proto.WeatherResponse {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]*proto.WeatherResponse, len(byCity))
+		sem     = make(chan struct{}, j.cfg.FetchConcurrency)
+	)
+
+	for key, subs := range byCity {
+		city := subs[0].City
+		wg.Add(1)
+		go func(key, city string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			weatherResp, err := j.weatherClient.GetWeather(ctx, &proto.WeatherRequest{City: city})
+			if err != nil {
+				metrics.WeatherJobFetchErrors.Inc()
+				j.logger.Errorf("failed to get weather for city=%s: %v", city, err)
+				return
+			}
+			metrics.WeatherJobCitiesFetched.Inc()
+
+			mu.Lock()
+			results[key] = weatherResp
+			mu.Unlock()
+		}(key, city)
+	}
+	wg.Wait()
+
+	j.logger.Infof("weather job fetched %d/%d cities", len(results), len(byCity))
+	return results
+}
+
+// notify publishes the weather update for s and reports whether it was published.
+func (j *WeatherUpdateJob) notify(ctx context.Context, s subscriptions.Subscription, weatherResp *proto.WeatherResponse) bool {
+	event := domain.WeatherUpdateEvent{
+		EventID:     uuid.NewString(),
+		Email:       s.ChannelValue,
+		ChannelType: s.ChannelType,
+		Metrics: domain.WeatherMetrics{
+			City:        s.City,
+			Description: weatherResp.Description,
+			Temperature: weatherResp.Temperature,
+			Humidity:    weatherResp.Humidity,
+		},
+		UpdatedAt: time.Now().Unix(),
+	}
+
+	if err := j.publisher.PublishWithTopic(ctx, "weather.updated", event); err != nil {
+		j.logger.Errorf("failed to publish weather update for user=%d: %v", s.ID, err)
+		return false
+	}
+	return true
+}
+
+func (j *WeatherUpdateJob) reschedule(ctx context.Context, s subscriptions.Subscription, now time.Time) {
+	next, err := schedule.Next(schedule.Schedule{
+		FrequencyMinutes: s.FrequencyMinutes,
+		DeliveryTime:     s.DeliveryTime,
+		Timezone:         s.Timezone,
+		Days:             s.DeliveryDays,
+	}, s.NextNotifiedAt, now)
+	if err != nil {
+		j.logger.Errorf("failed to compute next notification for user=%d: %v", s.ID, err)
+		return
+	}
+	if err := j.repo.UpdateNextNotification(ctx, int64(s.ID), next); err != nil {
+		j.logger.Errorf("failed to update next notification for user=%d: %v", s.ID, err)
 	}
 }
 
@@ -96,3 +209,7 @@ func (j *WeatherUpdateJob) StartPeriodic(ctx context.Context) {
 		}
 	}
 }
+
+func normalizeCity(city string) string {
+	return strings.ToLower(strings.TrimSpace(city))
+}
@@ -0,0 +1,185 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"subscription-service/internal/domain"
+	"subscription-service/internal/jobs"
+	"subscription-service/internal/observability/metrics"
+	"subscription-service/internal/proto"
+	"subscription-service/internal/repository/subscriptions"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dueSubscriptions struct {
+	mu          sync.Mutex
+	due         []subscriptions.Subscription
+	rescheduled []int64
+}
+
+func (r *dueSubscriptions) GetDueSubscriptions(context.Context) ([]subscriptions.Subscription, error) {
+	return r.due, nil
+}
+
+func (r *dueSubscriptions) UpdateNextNotification(_ context.Context, subscriptionID int64, _ time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rescheduled = append(r.rescheduled, subscriptionID)
+	return nil
+}
+
+// countingWeatherClient counts requests per city and the peak number of
+// requests in flight; requests for cities in fail return an error.
+type countingWeatherClient struct {
+	mu       sync.Mutex
+	calls    map[string]int
+	inFlight int
+	peak     int
+	fail     map[string]bool
+}
+
+func (c *countingWeatherClient) GetWeather(_ context.Context, req *proto.WeatherRequest) (*proto.WeatherResponse, error) {
+	c.mu.Lock()
+	c.calls[req.City]++
+	c.inFlight++
+	if c.inFlight > c.peak {
+		c.peak = c.inFlight
+	}
+	c.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight--
+	if c.fail[req.City] {
+		return nil, errors.New("weather-service unavailable")
+	}
+	return &proto.WeatherResponse{City: req.City, Description: "Sunny", Temperature: 20}, nil
+}
+
+type weatherEventRecorder struct {
+	mu     sync.Mutex
+	events []domain.WeatherUpdateEvent
+	failOn string
+}
+
+func (p *weatherEventRecorder) PublishWithTopic(_ context.Context, topic string, event any) error {
+	if topic != "weather.updated" {
+		return errors.New("unexpected topic " + topic)
+	}
+	e := event.(domain.WeatherUpdateEvent)
+	if e.Email == p.failOn {
+		return errors.New("broker unavailable")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, e)
+	return nil
+}
+
+func dueSubscription(id int, city, email string) subscriptions.Subscription {
+	return subscriptions.Subscription{
+		ID:               id,
+		ChannelType:      "email",
+		ChannelValue:     email,
+		City:             city,
+		FrequencyMinutes: 60,
+		Timezone:         "UTC",
+		NextNotifiedAt:   time.Now().Add(-time.Second),
+	}
+}
+
+func newWeatherJob(
+	repo *dueSubscriptions,
+	publisher *weatherEventRecorder,
+	client *countingWeatherClient,
+	concurrency int,
+) *jobs.WeatherUpdateJob {
+	return jobs.NewWeatherUpdateJob(repo, publisher, client, nopLogger{}, jobs.WeatherUpdateJobConfig{
+		MissedSlotTolerance: time.Minute,
+		FetchConcurrency:    concurrency,
+	})
+}
+
+func TestWeatherUpdateJob_FetchesEachCityOnce(t *testing.T) {
+	repo := &dueSubscriptions{due: []subscriptions.Subscription{
+		dueSubscription(1, "Kyiv", "a@example.com"),
+		dueSubscription(2, " kyiv ", "b@example.com"),
+		dueSubscription(3, "KYIV", "c@example.com"),
+		dueSubscription(4, "Lviv", "d@example.com"),
+	}}
+	client := &countingWeatherClient{calls: map[string]int{}}
+	publisher := &weatherEventRecorder{}
+
+	newWeatherJob(repo, publisher, client, 4).Run(context.Background())
+
+	total := 0
+	for _, n := range client.calls {
+		total += n
+	}
+	assert.Equal(t, 2, total, "one request per normalized city, got %v", client.calls)
+	assert.Equal(t, 1, client.calls["Lviv"])
+	assert.Len(t, publisher.events, 4)
+	assert.ElementsMatch(t, []int64{1, 2, 3, 4}, repo.rescheduled)
+}
+
+func TestWeatherUpdateJob_BoundsConcurrentFetches(t *testing.T) {
+	cities := []string{"Kyiv", "Lviv", "Odesa", "Dnipro", "Kharkiv", "Poltava"}
+	repo := &dueSubscriptions{}
+	for i, city := range cities {
+		repo.due = append(repo.due, dueSubscription(i+1, city, city+"@example.com"))
+	}
+	client := &countingWeatherClient{calls: map[string]int{}}
+
+	newWeatherJob(repo, &weatherEventRecorder{}, client, 2).Run(context.Background())
+
+	assert.Len(t, client.calls, len(cities))
+	assert.LessOrEqual(t, client.peak, 2)
+}
+
+func TestWeatherUpdateJob_SkipsSubscribersOfFailedCities(t *testing.T) {
+	repo := &dueSubscriptions{due: []subscriptions.Subscription{
+		dueSubscription(1, "Kyiv", "a@example.com"),
+		dueSubscription(2, "Lviv", "b@example.com"),
+		dueSubscription(3, "lviv", "c@example.com"),
+	}}
+	client := &countingWeatherClient{calls: map[string]int{}, fail: map[string]bool{"Lviv": true, "lviv": true}}
+	publisher := &weatherEventRecorder{}
+
+	newWeatherJob(repo, publisher, client, 2).Run(context.Background())
+
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, "a@example.com", publisher.events[0].Email)
+	assert.Equal(t, []int64{1}, repo.rescheduled, "subscribers of a failed city stay due for the next run")
+}
+
+func TestWeatherUpdateJob_Metrics(t *testing.T) {
+	repo := &dueSubscriptions{due: []subscriptions.Subscription{
+		dueSubscription(1, "Kyiv", "a@example.com"),
+		dueSubscription(2, "Kyiv", "b@example.com"),
+		dueSubscription(3, "Kyiv", "unreachable@example.com"),
+		dueSubscription(4, "Lviv", "d@example.com"),
+		dueSubscription(5, "Odesa", "e@example.com"),
+	}}
+	client := &countingWeatherClient{calls: map[string]int{}, fail: map[string]bool{"Odesa": true}}
+	publisher := &weatherEventRecorder{failOn: "unreachable@example.com"}
+
+	fetched := testutil.ToFloat64(metrics.WeatherJobCitiesFetched)
+	served := testutil.ToFloat64(metrics.WeatherJobSubscriptionsServed)
+	fetchErrors := testutil.ToFloat64(metrics.WeatherJobFetchErrors)
+
+	newWeatherJob(repo, publisher, client, 2).Run(context.Background())
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.WeatherJobCitiesFetched)-fetched)
+	assert.Equal(t, 3.0, testutil.ToFloat64(metrics.WeatherJobSubscriptionsServed)-served,
+		"a failed publish does not count as served")
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.WeatherJobFetchErrors)-fetchErrors)
+}
@@ -0,0 +1,3 @@
+ALTER TABLE subscriptions
+	ADD COLUMN delivery_time VARCHAR(5),
+	ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,8 @@
+CREATE TABLE command_requests (
+	request_id UUID PRIMARY KEY,
+	command VARCHAR(50) NOT NULL,
+	status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'succeeded', 'failed')),
+	reason TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
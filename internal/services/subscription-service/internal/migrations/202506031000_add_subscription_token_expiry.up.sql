@@ -0,0 +1,10 @@
+ALTER TABLE subscriptions
+	ADD COLUMN token_expires_at TIMESTAMP;
+
+UPDATE subscriptions
+SET token_expires_at = COALESCE(created_at, CURRENT_TIMESTAMP) + INTERVAL '24 hours'
+WHERE confirmed = FALSE;
+
+CREATE INDEX idx_subscriptions_unconfirmed_expiry
+	ON subscriptions (token_expires_at)
+	WHERE confirmed = FALSE;
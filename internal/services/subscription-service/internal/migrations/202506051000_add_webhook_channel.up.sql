@@ -0,0 +1,7 @@
+ALTER TABLE subscriptions
+	DROP CONSTRAINT IF EXISTS subscriptions_channel_type_check;
+
+-- For webhook subscriptions channel_value holds the endpoint URL.
+ALTER TABLE subscriptions
+	ADD CONSTRAINT subscriptions_channel_type_check
+	CHECK (channel_type IN ('email', 'sms', 'telegram', 'webhook'));
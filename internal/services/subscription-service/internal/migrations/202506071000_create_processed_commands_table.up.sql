@@ -0,0 +1,10 @@
+CREATE TABLE processed_commands (
+	command_id VARCHAR(64) PRIMARY KEY,
+	command VARCHAR(50) NOT NULL,
+	outcome VARCHAR(20) NOT NULL CHECK (outcome IN ('succeeded', 'failed')),
+	reason TEXT,
+	processed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_processed_commands_expires_at ON processed_commands (expires_at);
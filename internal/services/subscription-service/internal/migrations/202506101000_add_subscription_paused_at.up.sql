@@ -0,0 +1,4 @@
+-- Paused subscriptions keep their token and schedule but are skipped by the
+-- weather job until resumed.
+ALTER TABLE subscriptions
+	ADD COLUMN paused_at TIMESTAMP;
@@ -0,0 +1,4 @@
+-- Weekday restriction of a daily delivery time, e.g. 'Mon-Fri'; NULL means
+-- every day.
+ALTER TABLE subscriptions
+	ADD COLUMN delivery_days VARCHAR(64);
@@ -0,0 +1,2 @@
+-- Suppressions and manage links look recipients up case-insensitively.
+CREATE INDEX idx_subscriptions_channel_value_lower ON subscriptions (channel_type, lower(channel_value));
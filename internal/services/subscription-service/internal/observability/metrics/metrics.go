@@ -26,6 +26,60 @@ var (
 		Name:      "subscription_creation_errors_total",
 		Help:      "Total number of errors that occurred while creating subscriptions.",
 	})
+
+	UnconfirmedSubscriptionsPurged = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "subscription_service",
+		Name:      "unconfirmed_subscriptions_purged_total",
+		Help:      "Total number of unconfirmed subscriptions deleted after their confirmation token expired.",
+	})
+
+	SubscriptionsSuppressed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "subscription_service",
+		Name:      "subscriptions_suppressed_total",
+		Help:      "Total number of subscriptions cancelled because their recipient bounced or reported spam.",
+	})
+
+	OutboxPending = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "subscription_service",
+		Name:      "outbox_pending_messages",
+		Help:      "Number of outbox messages not yet published to Kafka.",
+	})
+
+	OutboxLagSeconds = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "subscription_service",
+		Name:      "outbox_lag_seconds",
+		Help:      "Age in seconds of the oldest unpublished outbox message.",
+	})
+
+	OutboxPublished = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "subscription_service",
+		Name:      "outbox_published_total",
+		Help:      "Total number of outbox messages published to Kafka.",
+	})
+
+	OutboxPublishErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "subscription_service",
+		Name:      "outbox_publish_errors_total",
+		Help:      "Total number of failed outbox relay batches.",
+	})
+
+	WeatherJobCitiesFetched = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "subscription_service",
+		Name:      "weather_job_cities_fetched_total",
+		Help:      "Total number of distinct cities fetched from weather-service by the weather update job.",
+	})
+
+	WeatherJobSubscriptionsServed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "subscription_service",
+		Name:      "weather_job_subscriptions_served_total",
+		Help:      "Total number of subscriptions notified by the weather update job.",
+	})
+
+	WeatherJobFetchErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "subscription_service",
+		Name:      "weather_job_fetch_errors_total",
+		Help:      "Total number of failed weather-service calls made by the weather update job.",
+	})
 )
 
 var registered bool
@@ -43,6 +97,15 @@ func Register() error {
 		ActiveSubscriptions,
 		SubscriptionsCreated,
 		SubscriptionCreationErrors,
+		UnconfirmedSubscriptionsPurged,
+		SubscriptionsSuppressed,
+		OutboxPending,
+		OutboxLagSeconds,
+		OutboxPublished,
+		OutboxPublishErrors,
+		WeatherJobCitiesFetched,
+		WeatherJobSubscriptionsServed,
+		WeatherJobFetchErrors,
 		collectors.NewGoCollector(),
 		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
 	}
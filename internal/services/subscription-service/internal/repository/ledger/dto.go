@@ -0,0 +1,17 @@
+package ledger
+
+import "time"
+
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeFailed    = "failed"
+)
+
+type Entry struct {
+	CommandID   string
+	Command     string
+	Outcome     string
+	Reason      string
+	ProcessedAt time.Time
+	ExpiresAt   time.Time
+}
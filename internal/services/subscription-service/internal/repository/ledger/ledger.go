@@ -0,0 +1,87 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrAlreadyRecorded means another delivery of the same command recorded its
+// outcome first.
+var ErrAlreadyRecorded = errors.New("command outcome already recorded")
+
+type databaseManager interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Lookup returns the unexpired outcome recorded for commandID, or nil if the
+// command has not been processed.
+func Lookup(ctx context.Context, db databaseManager, commandID string) (*Entry, error) {
+	var (
+		e      Entry
+		reason sql.NullString
+	)
+	err := db.QueryRowContext(ctx, `
+		SELECT command_id, command, outcome, reason, processed_at, expires_at
+		FROM processed_commands
+		WHERE command_id = $1 AND expires_at > $2`,
+		commandID, time.Now().UTC(),
+	).Scan(&e.CommandID, &e.Command, &e.Outcome, &reason, &e.ProcessedAt, &e.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up command %s: %w", commandID, err)
+	}
+	e.Reason = reason.String
+	return &e, nil
+}
+
+// Record stores the outcome of a command, replacing an expired entry. It
+// returns ErrAlreadyRecorded if an unexpired entry exists.
+func Record(ctx context.Context, db databaseManager, e Entry) error {
+	now := time.Now().UTC()
+	result, err := db.ExecContext(ctx, `
+		INSERT INTO processed_commands (command_id, command, outcome, reason, processed_at, expires_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6)
+		ON CONFLICT (command_id) DO UPDATE
+		SET command = EXCLUDED.command, outcome = EXCLUDED.outcome, reason = EXCLUDED.reason,
+			processed_at = EXCLUDED.processed_at, expires_at = EXCLUDED.expires_at
+		WHERE processed_commands.expires_at <= EXCLUDED.processed_at`,
+		e.CommandID, e.Command, e.Outcome, e.Reason, now, e.ExpiresAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record command %s: %w", e.CommandID, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after recording command %s: %w", e.CommandID, err)
+	}
+	if rows == 0 {
+		return ErrAlreadyRecorded
+	}
+	return nil
+}
+
+type Repository struct {
+	db databaseManager
+}
+
+func New(db databaseManager) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM processed_commands WHERE expires_at <= $1`, now.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired commands: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected after command cleanup: %w", err)
+	}
+	return rows, nil
+}
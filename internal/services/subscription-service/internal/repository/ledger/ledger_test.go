@@ -0,0 +1,89 @@
+package ledger_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"subscription-service/internal/repository/ledger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	selectCommand = regexp.QuoteMeta(`SELECT command_id, command, outcome, reason, processed_at, expires_at`)
+	insertCommand = regexp.QuoteMeta(`INSERT INTO processed_commands`)
+)
+
+func TestLookup_UnknownCommand(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(selectCommand).WithArgs("cmd-1", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"command_id", "command", "outcome", "reason", "processed_at", "expires_at"}))
+
+	entry, err := ledger.Lookup(context.Background(), db, "cmd-1")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLookup_RecordedCommand(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	processedAt := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(selectCommand).WithArgs("cmd-1", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"command_id", "command", "outcome", "reason", "processed_at", "expires_at"}).
+			AddRow("cmd-1", "pause", ledger.OutcomeFailed, nil, processedAt, processedAt.Add(time.Hour)))
+
+	entry, err := ledger.Lookup(context.Background(), db, "cmd-1")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, ledger.OutcomeFailed, entry.Outcome)
+	assert.Empty(t, entry.Reason)
+	assert.Equal(t, processedAt, entry.ProcessedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecord(t *testing.T) {
+	entry := ledger.Entry{
+		CommandID: "cmd-1",
+		Command:   "pause",
+		Outcome:   ledger.OutcomeFailed,
+		Reason:    "subscription not found",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{
+		{name: "stored", rows: 1},
+		{name: "unexpired entry exists", rows: 0, wantErr: ledger.ErrAlreadyRecorded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec(insertCommand).
+				WithArgs("cmd-1", "pause", ledger.OutcomeFailed, "subscription not found", sqlmock.AnyArg(), entry.ExpiresAt.UTC()).
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+
+			err = ledger.Record(context.Background(), db, entry)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package outbox
+
+import "time"
+
+type Message struct {
+	ID        int64
+	Topic     string
+	Key       string
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+type PendingStats struct {
+	Count     int64
+	OldestAge time.Duration
+}
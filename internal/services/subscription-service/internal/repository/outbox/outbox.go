@@ -0,0 +1,150 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type execerManager interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type databaseManager interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Enqueue stores event for later publication. Pass the transaction that
+// performs the related state change so both commit or roll back together.
+func Enqueue(ctx context.Context, db execerManager, topic, key string, event any) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox event for %s: %w", topic, err)
+	}
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO outbox (topic, message_key, payload)
+		VALUES ($1, NULLIF($2, ''), $3)`,
+		topic, key, payload,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue outbox event for %s: %w", topic, err)
+	}
+	return nil
+}
+
+type Repository struct {
+	db databaseManager
+}
+
+func New(db databaseManager) *Repository {
+	return &Repository{db: db}
+}
+
+// RelayBatch locks up to limit unsent messages in id order, hands each to
+// publish and marks the published ones sent. It stops at the first publish
+// error so later messages never overtake earlier ones; concurrent relays
+// block on the row locks instead of publishing out of order.
+func (r *Repository) RelayBatch(ctx context.Context, limit int, publish func(Message) error) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin outbox transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	messages, err := fetchPending(ctx, tx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		sent       []int64
+		publishErr error
+	)
+	for _, m := range messages {
+		if publishErr = publish(m); publishErr != nil {
+			break
+		}
+		sent = append(sent, m.ID)
+	}
+
+	for _, id := range sent {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE outbox SET sent_at = $1 WHERE id = $2`, time.Now().UTC(), id,
+		); err != nil {
+			return 0, fmt.Errorf("failed to mark outbox message %d sent: %w", id, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit outbox transaction: %w", err)
+	}
+	if publishErr != nil {
+		return len(sent), fmt.Errorf("failed to publish outbox message: %w", publishErr)
+	}
+	return len(sent), nil
+}
+
+func (r *Repository) PendingStats(ctx context.Context) (PendingStats, error) {
+	var (
+		stats  PendingStats
+		oldest sql.NullTime
+	)
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*), MIN(created_at) FROM outbox WHERE sent_at IS NULL`,
+	).Scan(&stats.Count, &oldest)
+	if err != nil {
+		return stats, fmt.Errorf("failed to get outbox stats: %w", err)
+	}
+	if oldest.Valid {
+		stats.OldestAge = time.Now().UTC().Sub(oldest.Time)
+	}
+	return stats, nil
+}
+
+func (r *Repository) DeleteSentBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM outbox WHERE sent_at IS NOT NULL AND sent_at < $1`, before.UTC(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sent outbox messages: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected after outbox cleanup: %w", err)
+	}
+	return rows, nil
+}
+
+func fetchPending(ctx context.Context, tx *sql.Tx, limit int) ([]Message, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, topic, COALESCE(message_key, ''), payload, created_at
+		FROM outbox
+		WHERE sent_at IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending outbox messages: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var messages []Message
+	for rows.Next() {
+		var m Message
+		if err := rows.Scan(&m.ID, &m.Topic, &m.Key, &m.Payload, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		messages = append(messages, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get pending outbox messages: %w", err)
+	}
+	return messages, nil
+}
@@ -0,0 +1,92 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"subscription-service/internal/repository/outbox"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var selectPending = regexp.QuoteMeta(`SELECT id, topic, COALESCE(message_key, ''), payload, created_at`)
+
+func pendingRows(ids ...int64) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "topic", "message_key", "payload", "created_at"})
+	for _, id := range ids {
+		rows.AddRow(id, "subscription.events", "key", []byte(`{}`), time.Now())
+	}
+	return rows
+}
+
+func TestRelayBatch_MarksPublishedMessagesSent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectPending).WithArgs(10).WillReturnRows(pendingRows(1, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE outbox SET sent_at`)).
+		WithArgs(sqlmock.AnyArg(), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE outbox SET sent_at`)).
+		WithArgs(sqlmock.AnyArg(), int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	var published []int64
+	sent, err := outbox.New(db).RelayBatch(context.Background(), 10, func(m outbox.Message) error {
+		published = append(published, m.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, []int64{1, 2}, published)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRelayBatch_StopsAtFirstPublishError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectPending).WithArgs(10).WillReturnRows(pendingRows(1, 2, 3))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE outbox SET sent_at`)).
+		WithArgs(sqlmock.AnyArg(), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	publishErr := errors.New("broker unavailable")
+	var attempted []int64
+	sent, err := outbox.New(db).RelayBatch(context.Background(), 10, func(m outbox.Message) error {
+		attempted = append(attempted, m.ID)
+		if m.ID == 2 {
+			return publishErr
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, publishErr)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []int64{1, 2}, attempted, "messages after a failure must not be published")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRelayBatch_RollsBackWhenMarkingFails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectPending).WithArgs(10).WillReturnRows(pendingRows(1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE outbox SET sent_at`)).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	sent, err := outbox.New(db).RelayBatch(context.Background(), 10, func(outbox.Message) error { return nil })
+	require.Error(t, err)
+	assert.Zero(t, sent)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,18 @@
+package requests
+
+import "time"
+
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+type Request struct {
+	RequestID string
+	Command   string
+	Status    string
+	Reason    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,74 @@
+package requests
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var ErrNotFound = errors.New("request not found")
+
+type databaseManager interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type Repository struct {
+	db databaseManager
+}
+
+func New(db databaseManager) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) MarkPending(ctx context.Context, requestID, command string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO command_requests (request_id, command, status)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (request_id) DO NOTHING`,
+		requestID, command, StatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record pending request %s: %w", requestID, err)
+	}
+	return nil
+}
+
+func (r *Repository) MarkSucceeded(ctx context.Context, requestID string) error {
+	return r.setStatus(ctx, requestID, StatusSucceeded, "")
+}
+
+func (r *Repository) MarkFailed(ctx context.Context, requestID, reason string) error {
+	return r.setStatus(ctx, requestID, StatusFailed, reason)
+}
+
+func (r *Repository) setStatus(ctx context.Context, requestID, status, reason string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE command_requests
+		SET status = $2, reason = NULLIF($3, ''), updated_at = CURRENT_TIMESTAMP
+		WHERE request_id = $1`,
+		requestID, status, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set status %s for request %s: %w", status, requestID, err)
+	}
+	return nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, requestID string) (*Request, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT request_id, command, status, COALESCE(reason, ''), created_at, updated_at
+		FROM command_requests
+		WHERE request_id = $1
+	`, requestID)
+	var req Request
+	err := row.Scan(&req.RequestID, &req.Command, &req.Status, &req.Reason, &req.CreatedAt, &req.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get request '%s': %w", requestID, err)
+	}
+	return &req, nil
+}
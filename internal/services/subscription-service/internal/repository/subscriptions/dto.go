@@ -10,6 +10,11 @@ type Subscription struct {
 	FrequencyMinutes int
 	Confirmed        bool
 	Token            string
+	TokenExpiresAt   time.Time
+	DeliveryTime     string
+	DeliveryDays     string
+	Timezone         string
 	NextNotifiedAt   time.Time
+	PausedAt         *time.Time
 	CreatedAt        time.Time
 }
\ No newline at end of file
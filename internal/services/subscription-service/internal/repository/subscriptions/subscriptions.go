@@ -5,51 +5,180 @@ import (
 	"database/sql"
 	"errors"
 	"subscription-service/internal/observability/metrics"
+	"subscription-service/internal/repository/ledger"
+	"subscription-service/internal/repository/outbox"
 	"fmt"
 	"strings"
 	"time"
 )
 
+var (
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+	ErrAlreadySubscribed    = errors.New("already subscribed")
+	ErrTokenExpired         = errors.New("confirmation token expired")
+	ErrAlreadyPaused        = errors.New("subscription already paused")
+	ErrNotPaused            = errors.New("subscription is not paused")
+)
+
 type databaseManager interface {
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
 	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
+type txBeginnerManager interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
 type Repository struct {
 	db databaseManager
+	// afterCommit holds metric updates deferred until the transaction commits;
+	// it is nil outside WithTx.
+	afterCommit *[]func()
 }
 
 func New(db databaseManager) *Repository {
 	return &Repository{db: db}
 }
 
+// WithTx runs fn against a repository bound to a single transaction, so that
+// subscription changes and their outbox events are committed together.
+func (r *Repository) WithTx(ctx context.Context, fn func(tx *Repository) error) error {
+	beginner, ok := r.db.(txBeginnerManager)
+	if !ok {
+		return fn(r)
+	}
+	tx, err := beginner.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	var afterCommit []func()
+	if err := fn(&Repository{db: tx, afterCommit: &afterCommit}); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	for _, f := range afterCommit {
+		f()
+	}
+	return nil
+}
+
+// onCommit runs f once the enclosing transaction commits, or immediately
+// outside a transaction, so metrics never count changes that rolled back.
+func (r *Repository) onCommit(f func()) {
+	if r.afterCommit == nil {
+		f()
+		return
+	}
+	*r.afterCommit = append(*r.afterCommit, f)
+}
+
+// LookupCommand returns the recorded outcome of commandID, or nil if it has
+// not been processed.
+func (r *Repository) LookupCommand(ctx context.Context, commandID string) (*ledger.Entry, error) {
+	return ledger.Lookup(ctx, r.db, commandID)
+}
+
+// RecordCommand stores a command outcome; call it inside WithTx for successes
+// so the ledger entry commits with the state change.
+func (r *Repository) RecordCommand(ctx context.Context, e ledger.Entry) error {
+	return ledger.Record(ctx, r.db, e)
+}
+
+// EnqueueEvent writes event to the outbox; call it inside WithTx.
+func (r *Repository) EnqueueEvent(ctx context.Context, topic, key string, event any) error {
+	return outbox.Enqueue(ctx, r.db, topic, key, event)
+}
+
+// CreateSubscription inserts sub. A duplicate channel/city is reported as
+// ErrAlreadySubscribed via ON CONFLICT rather than a unique violation, which
+// would abort an enclosing transaction.
 func (r *Repository) CreateSubscription(ctx context.Context, sub *Subscription) error {
-	_, err := r.db.ExecContext(ctx, `
+	result, err := r.db.ExecContext(ctx, `
 		INSERT INTO subscriptions 
-		(channel_type, channel_value, city, frequency_minutes, token, next_notified_at)
-		VALUES ($1, $2, $3, $4, $5, NOW() + ($6 * interval '1 minute'))`,
+		(channel_type, channel_value, city, frequency_minutes, token, delivery_time, timezone, next_notified_at, token_expires_at, delivery_days)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, NULLIF($10, ''))
+		ON CONFLICT (channel_type, channel_value, city) DO NOTHING`,
 		sub.ChannelType, sub.ChannelValue, sub.City,
-		sub.FrequencyMinutes, sub.Token, sub.FrequencyMinutes,
+		sub.FrequencyMinutes, sub.Token, sub.DeliveryTime, sub.Timezone, sub.NextNotifiedAt.UTC(),
+		sub.TokenExpiresAt.UTC(), sub.DeliveryDays,
 	)
-
 	if err != nil {
 		metrics.SubscriptionCreationErrors.Inc()
 		if strings.Contains(err.Error(), "unique") {
-			return errors.New("already subscribed")
+			return ErrAlreadySubscribed
 		}
 		return err
 	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		metrics.SubscriptionCreationErrors.Inc()
+		return fmt.Errorf("failed to get rows affected after insert: %w", err)
+	}
+	if rows == 0 {
+		metrics.SubscriptionCreationErrors.Inc()
+		return ErrAlreadySubscribed
+	}
 
-	metrics.SubscriptionsCreated.Inc()
-	metrics.ActiveSubscriptions.Inc()
+	r.onCommit(func() {
+		metrics.SubscriptionsCreated.Inc()
+		metrics.ActiveSubscriptions.Inc()
+	})
+	return nil
+}
+
+// RefreshUnconfirmed replaces the token, expiry and schedule of a pending
+// subscription matching sub's channel and city. It returns
+// ErrSubscriptionNotFound when no unconfirmed subscription exists.
+func (r *Repository) RefreshUnconfirmed(ctx context.Context, sub *Subscription) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE subscriptions
+		SET token = $1, token_expires_at = $2, frequency_minutes = $3,
+			delivery_time = NULLIF($4, ''), timezone = $5, next_notified_at = $6, delivery_days = NULLIF($10, '')
+		WHERE channel_type = $7 AND channel_value = $8 AND city = $9 AND confirmed = FALSE`,
+		sub.Token, sub.TokenExpiresAt.UTC(), sub.FrequencyMinutes,
+		sub.DeliveryTime, sub.Timezone, sub.NextNotifiedAt.UTC(),
+		sub.ChannelType, sub.ChannelValue, sub.City, sub.DeliveryDays,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to refresh unconfirmed subscription: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after refresh: %w", err)
+	}
+	if rows == 0 {
+		return ErrSubscriptionNotFound
+	}
 	return nil
 }
 
 func (r *Repository) ConfirmByToken(ctx context.Context, token string) error {
+	var (
+		confirmed bool
+		expiresAt sql.NullTime
+	)
+	err := r.db.QueryRowContext(ctx,
+		`SELECT confirmed, token_expires_at FROM subscriptions WHERE token = $1`, token,
+	).Scan(&confirmed, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrSubscriptionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get subscription for confirmation: %w", err)
+	}
+	if !confirmed && expiresAt.Valid && !expiresAt.Time.After(time.Now().UTC()) {
+		return ErrTokenExpired
+	}
+
 	result, err := r.db.ExecContext(ctx, `
 		UPDATE subscriptions
-		SET confirmed = TRUE
+		SET confirmed = TRUE, token_expires_at = NULL
 		WHERE token = $1`, token)
 	if err != nil {
 		return fmt.Errorf("failed to update subscription confirmation: %w", err)
@@ -59,11 +188,32 @@ func (r *Repository) ConfirmByToken(ctx context.Context, token string) error {
 		return fmt.Errorf("failed to get rows affected after confirmation update: %w", err)
 	}
 	if rows == 0 {
-		return errors.New("subscription not found")
+		return ErrSubscriptionNotFound
 	}
 	return nil
 }
 
+// PurgeExpiredUnconfirmed deletes unconfirmed subscriptions whose token
+// expired at or before now and returns how many rows were removed.
+func (r *Repository) PurgeExpiredUnconfirmed(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM subscriptions
+		WHERE confirmed = FALSE AND token_expires_at <= $1`, now.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge unconfirmed subscriptions: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected after purge: %w", err)
+	}
+
+	r.onCommit(func() {
+		metrics.UnconfirmedSubscriptionsPurged.Add(float64(rows))
+		metrics.ActiveSubscriptions.Sub(float64(rows))
+	})
+	return rows, nil
+}
+
 func (r *Repository) UnsubscribeByToken(ctx context.Context, token string) error {
 	result, err := r.db.ExecContext(ctx, `DELETE FROM subscriptions WHERE token = $1`, token)
 	if err != nil {
@@ -74,18 +224,162 @@ func (r *Repository) UnsubscribeByToken(ctx context.Context, token string) error
 		return fmt.Errorf("failed to get rows affected after deletion: %w", err)
 	}
 	if rows == 0 {
-		return errors.New("subscription not found")
+		return ErrSubscriptionNotFound
+	}
+
+	r.onCommit(metrics.ActiveSubscriptions.Dec)
+	return nil
+}
+
+// DeleteByChannelValue removes every subscription of a recipient, confirmed
+// or not, matching the address case-insensitively, and returns how many rows
+// were removed.
+func (r *Repository) DeleteByChannelValue(ctx context.Context, channelType, channelValue string) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM subscriptions
+		WHERE channel_type = $1 AND lower(channel_value) = lower($2)`, channelType, channelValue)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete subscriptions of recipient: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected after deletion: %w", err)
+	}
+
+	r.onCommit(func() {
+		metrics.SubscriptionsSuppressed.Add(float64(rows))
+		metrics.ActiveSubscriptions.Sub(float64(rows))
+	})
+	return rows, nil
+}
+
+// UpdateSchedule replaces the frequency, delivery time and days, time zone and
+// next notification of the subscription with sub.Token.
+func (r *Repository) UpdateSchedule(ctx context.Context, sub *Subscription) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE subscriptions
+		SET frequency_minutes = $1, delivery_time = NULLIF($2, ''), timezone = $3, next_notified_at = $4,
+			delivery_days = NULLIF($6, '')
+		WHERE token = $5`,
+		sub.FrequencyMinutes, sub.DeliveryTime, sub.Timezone, sub.NextNotifiedAt.UTC(), sub.Token,
+		sub.DeliveryDays,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update subscription schedule: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after schedule update: %w", err)
+	}
+	if rows == 0 {
+		return ErrSubscriptionNotFound
 	}
+	return nil
+}
 
-	metrics.ActiveSubscriptions.Dec()
+// PauseByToken stops notifications for a subscription until it is resumed.
+func (r *Repository) PauseByToken(ctx context.Context, token string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE subscriptions
+		SET paused_at = $1
+		WHERE token = $2 AND paused_at IS NULL`, time.Now().UTC(), token)
+	if err != nil {
+		return fmt.Errorf("failed to pause subscription: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after pause: %w", err)
+	}
+	if rows == 0 {
+		return r.pausedStateError(ctx, token, ErrAlreadyPaused)
+	}
 	return nil
 }
 
+// ResumeByToken restarts notifications for a paused subscription, with the
+// next one due at next.
+func (r *Repository) ResumeByToken(ctx context.Context, token string, next time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE subscriptions
+		SET paused_at = NULL, next_notified_at = $1
+		WHERE token = $2 AND paused_at IS NOT NULL`, next.UTC(), token)
+	if err != nil {
+		return fmt.Errorf("failed to resume subscription: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after resume: %w", err)
+	}
+	if rows == 0 {
+		return r.pausedStateError(ctx, token, ErrNotPaused)
+	}
+	return nil
+}
+
+// pausedStateError explains why a pause or resume matched no row: either the
+// token is unknown or the subscription is already in the requested state.
+func (r *Repository) pausedStateError(ctx context.Context, token string, stateErr error) error {
+	var exists bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM subscriptions WHERE token = $1)`, token,
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check subscription state: %w", err)
+	}
+	if !exists {
+		return ErrSubscriptionNotFound
+	}
+	return stateErr
+}
+
+// ListByChannelValue returns every subscription of a recipient, confirmed or
+// not, matching the address case-insensitively, oldest first.
+func (r *Repository) ListByChannelValue(ctx context.Context, channelType, channelValue string) ([]Subscription, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, channel_type, channel_value, city, frequency_minutes, confirmed, token,
+			COALESCE(delivery_time, ''), COALESCE(delivery_days, ''), timezone, next_notified_at, paused_at, created_at
+		FROM subscriptions
+		WHERE channel_type = $1 AND lower(channel_value) = lower($2)
+		ORDER BY created_at, id`, channelType, channelValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions of recipient: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			return
+		}
+	}()
+
+	var subs []Subscription
+	for rows.Next() {
+		var (
+			s        Subscription
+			pausedAt sql.NullTime
+		)
+		if err := rows.Scan(
+			&s.ID, &s.ChannelType, &s.ChannelValue, &s.City, &s.FrequencyMinutes, &s.Confirmed, &s.Token,
+			&s.DeliveryTime, &s.DeliveryDays, &s.Timezone, &s.NextNotifiedAt, &pausedAt, &s.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan subscription of recipient: %w", err)
+		}
+		if pausedAt.Valid {
+			s.PausedAt = &pausedAt.Time
+		}
+		subs = append(subs, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions of recipient: %w", err)
+	}
+	return subs, nil
+}
+
 func (r *Repository) GetDueSubscriptions(ctx context.Context) ([]Subscription, error) {
 	rows, err := r.db.QueryContext(ctx,
-		`SELECT id, channel_type, channel_value, city, frequency_minutes
+		`SELECT id, channel_type, channel_value, city, frequency_minutes,
+			COALESCE(delivery_time, ''), COALESCE(delivery_days, ''), timezone, next_notified_at
 		FROM subscriptions
-		WHERE confirmed = TRUE AND next_notified_at <= NOW()`,
+		WHERE confirmed = TRUE AND paused_at IS NULL AND next_notified_at <= $1`,
+		time.Now().UTC(),
 	)
 	var subs []Subscription
 	if err != nil {
@@ -99,7 +393,10 @@ func (r *Repository) GetDueSubscriptions(ctx context.Context) ([]Subscription, e
 	
 	for rows.Next() {
 		var s Subscription
-		if err := rows.Scan(&s.ID, &s.ChannelType, &s.ChannelValue, &s.City, &s.FrequencyMinutes); err != nil {
+		if err := rows.Scan(
+			&s.ID, &s.ChannelType, &s.ChannelValue, &s.City, &s.FrequencyMinutes,
+			&s.DeliveryTime, &s.DeliveryDays, &s.Timezone, &s.NextNotifiedAt,
+		); err != nil {
 			return subs, fmt.Errorf("failed to scan due subscriptions: %w", err)
 		}
 		subs = append(subs, s)
@@ -111,7 +408,7 @@ func (r *Repository) GetDueSubscriptions(ctx context.Context) ([]Subscription, e
 }
 
 func (r *Repository) UpdateNextNotification(ctx context.Context, id int64, next time.Time) error {
-	_, err := r.db.ExecContext(ctx, `UPDATE subscriptions SET next_notified_at = $1 WHERE id = $2`, next, id)
+	_, err := r.db.ExecContext(ctx, `UPDATE subscriptions SET next_notified_at = $1 WHERE id = $2`, next.UTC(), id)
 	if err != nil {
 		return fmt.Errorf("failed to update next_notified_at for id %d: %w", id, err)
 	}
@@ -120,11 +417,15 @@ func (r *Repository) UpdateNextNotification(ctx context.Context, id int64, next
 
 func (r *Repository) GetSubscriptionByToken(ctx context.Context, token string) (*Subscription, error) {
 	row := r.db.QueryRowContext(ctx, `
-		SELECT id, channel_type, channel_value, city, frequency_minutes, confirmed, token, next_notified_at, created_at
+		SELECT id, channel_type, channel_value, city, frequency_minutes, confirmed, token,
+			COALESCE(delivery_time, ''), COALESCE(delivery_days, ''), timezone, next_notified_at, paused_at, created_at
 		FROM subscriptions
 		WHERE token = $1
 	`, token)
-	var sub Subscription
+	var (
+		sub      Subscription
+		pausedAt sql.NullTime
+	)
 	err := row.Scan(
 		&sub.ID,
 		&sub.ChannelType,
@@ -133,14 +434,21 @@ func (r *Repository) GetSubscriptionByToken(ctx context.Context, token string) (
 		&sub.FrequencyMinutes,
 		&sub.Confirmed,
 		&sub.Token,
+		&sub.DeliveryTime,
+		&sub.DeliveryDays,
+		&sub.Timezone,
 		&sub.NextNotifiedAt,
+		&pausedAt,
 		&sub.CreatedAt,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
-		return nil, errors.New("subscription not found")
+		return nil, ErrSubscriptionNotFound
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get subscription by token '%s': %w", token, err)
 	}
+	if pausedAt.Valid {
+		sub.PausedAt = &pausedAt.Time
+	}
 	return &sub, nil
 }
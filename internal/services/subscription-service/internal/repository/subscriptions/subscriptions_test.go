@@ -0,0 +1,255 @@
+package subscriptions_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"subscription-service/internal/repository/subscriptions"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteByChannelValue_IgnoresCase(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`WHERE channel_type = $1 AND lower(channel_value) = lower($2)`)).
+		WithArgs("email", "User@Example.com").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	deleted, err := subscriptions.New(db).DeleteByChannelValue(context.Background(), "email", "User@Example.com")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListByChannelValue_IgnoresCase(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE channel_type = $1 AND lower(channel_value) = lower($2)`)).
+		WithArgs("email", "User@Example.com").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "channel_type", "channel_value", "city", "frequency_minutes", "confirmed", "token",
+			"delivery_time", "delivery_days", "timezone", "next_notified_at", "paused_at", "created_at",
+		}))
+
+	subs, err := subscriptions.New(db).ListByChannelValue(context.Background(), "email", "User@Example.com")
+	require.NoError(t, err)
+	assert.Empty(t, subs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+var (
+	pauseUpdate  = regexp.QuoteMeta(`SET paused_at = $1`)
+	resumeUpdate = regexp.QuoteMeta(`SET paused_at = NULL`)
+	tokenExists  = regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM subscriptions WHERE token = $1)`)
+)
+
+func TestPauseAndResumeByToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  string
+		rows    int64
+		exists  *bool
+		wantErr error
+	}{
+		{name: "pause", update: pauseUpdate, rows: 1},
+		{name: "pause already paused", update: pauseUpdate, exists: ptr(true), wantErr: subscriptions.ErrAlreadyPaused},
+		{name: "pause unknown token", update: pauseUpdate, exists: ptr(false), wantErr: subscriptions.ErrSubscriptionNotFound},
+		{name: "resume", update: resumeUpdate, rows: 1},
+		{name: "resume not paused", update: resumeUpdate, exists: ptr(true), wantErr: subscriptions.ErrNotPaused},
+		{name: "resume unknown token", update: resumeUpdate, exists: ptr(false), wantErr: subscriptions.ErrSubscriptionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec(tt.update).WithArgs(sqlmock.AnyArg(), "token").
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+			if tt.exists != nil {
+				mock.ExpectQuery(tokenExists).WithArgs("token").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(*tt.exists))
+			}
+
+			repo := subscriptions.New(db)
+			if tt.update == pauseUpdate {
+				err = repo.PauseByToken(context.Background(), "token")
+			} else {
+				err = repo.ResumeByToken(context.Background(), "token", time.Now())
+			}
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateSchedule(t *testing.T) {
+	next := time.Date(2025, 6, 2, 4, 30, 0, 0, time.UTC)
+	sub := &subscriptions.Subscription{
+		Token:            "token",
+		FrequencyMinutes: 1440,
+		DeliveryTime:     "07:30",
+		DeliveryDays:     "Mon-Fri",
+		Timezone:         "Europe/Kyiv",
+		NextNotifiedAt:   next,
+	}
+	for _, tt := range []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{
+		{name: "updated", rows: 1},
+		{name: "unknown token", rows: 0, wantErr: subscriptions.ErrSubscriptionNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE subscriptions`)).
+				WithArgs(1440, "07:30", "Europe/Kyiv", next, "token", "Mon-Fri").
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+
+			err = subscriptions.New(db).UpdateSchedule(context.Background(), sub)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+var confirmLookup = regexp.QuoteMeta(`SELECT confirmed, token_expires_at FROM subscriptions WHERE token = $1`)
+
+func TestConfirmByToken(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	tests := []struct {
+		name       string
+		confirmed  bool
+		expiresAt  any
+		wantUpdate bool
+		wantErr    error
+	}{
+		{name: "pending token", expiresAt: future, wantUpdate: true},
+		{name: "expired token", expiresAt: past, wantErr: subscriptions.ErrTokenExpired},
+		{name: "confirmed row ignores expiry", confirmed: true, expiresAt: past, wantUpdate: true},
+		{name: "no expiry", expiresAt: nil, wantUpdate: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectQuery(confirmLookup).WithArgs("token").
+				WillReturnRows(sqlmock.NewRows([]string{"confirmed", "token_expires_at"}).AddRow(tt.confirmed, tt.expiresAt))
+			if tt.wantUpdate {
+				mock.ExpectExec(regexp.QuoteMeta(`SET confirmed = TRUE, token_expires_at = NULL`)).WithArgs("token").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			err = subscriptions.New(db).ConfirmByToken(context.Background(), "token")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestConfirmByToken_UnknownToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(confirmLookup).WithArgs("token").
+		WillReturnRows(sqlmock.NewRows([]string{"confirmed", "token_expires_at"}))
+
+	err = subscriptions.New(db).ConfirmByToken(context.Background(), "token")
+	require.ErrorIs(t, err, subscriptions.ErrSubscriptionNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeExpiredUnconfirmed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.FixedZone("EEST", 3*60*60))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM subscriptions
+		WHERE confirmed = FALSE AND token_expires_at <= $1`)).
+		WithArgs(now.UTC()).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	purged, err := subscriptions.New(db).PurgeExpiredUnconfirmed(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshUnconfirmed(t *testing.T) {
+	expiresAt := time.Date(2025, 6, 11, 12, 0, 0, 0, time.UTC)
+	next := time.Date(2025, 6, 10, 13, 0, 0, 0, time.UTC)
+	sub := &subscriptions.Subscription{
+		ChannelType:      "email",
+		ChannelValue:     "user@example.com",
+		City:             "Kyiv",
+		FrequencyMinutes: 1440,
+		Token:            "rotated-token",
+		TokenExpiresAt:   expiresAt,
+		DeliveryTime:     "08:00",
+		Timezone:         "Europe/Kyiv",
+		NextNotifiedAt:   next,
+	}
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{
+		{name: "pending subscription", rows: 1},
+		{name: "only a confirmed subscription", rows: 0, wantErr: subscriptions.ErrSubscriptionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec(regexp.QuoteMeta(`SET token = $1, token_expires_at = $2`) +
+				`(?s).*` + regexp.QuoteMeta(`AND city = $9 AND confirmed = FALSE`)).
+				WithArgs("rotated-token", expiresAt, 1440, "08:00", "Europe/Kyiv", next,
+					"email", "user@example.com", "Kyiv", "").
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+
+			err = subscriptions.New(db).RefreshUnconfirmed(context.Background(), sub)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	minutesPerHour     = 60
+	minutesPerDay      = 24 * minutesPerHour
+	deliveryTimeLayout = "15:04"
+	defaultTimezone    = "UTC"
+)
+
+type CatchUpPolicy string
+
+const (
+	// CatchUpSendOnce delivers a single notification for all slots missed during downtime.
+	CatchUpSendOnce CatchUpPolicy = "send_once"
+	// CatchUpSkip drops slots that are older than the missed slot tolerance.
+	CatchUpSkip CatchUpPolicy = "skip"
+)
+
+// Schedule describes when a subscription is notified. Intervals shorter than
+// a day run every FrequencyMinutes; daily schedules may fix a DeliveryTime in
+// Timezone and restrict it to Days, a weekday list such as "Mon-Fri" or
+// "Mon,Wed,Fri" (empty means every day).
+type Schedule struct {
+	FrequencyMinutes int
+	DeliveryTime     string
+	Timezone         string
+	Days             string
+}
+
+func ParseCatchUpPolicy(value string) (CatchUpPolicy, error) {
+	switch CatchUpPolicy(value) {
+	case CatchUpSendOnce, CatchUpSkip:
+		return CatchUpPolicy(value), nil
+	default:
+		return "", fmt.Errorf("unknown catch-up policy: %s", value)
+	}
+}
+
+func Validate(s Schedule) error {
+	if s.FrequencyMinutes <= 0 {
+		return fmt.Errorf("frequency must be positive")
+	}
+	if s.FrequencyMinutes < minutesPerDay &&
+		(s.FrequencyMinutes%minutesPerHour != 0 || minutesPerDay%s.FrequencyMinutes != 0) {
+		return fmt.Errorf("interval of %d minutes must be a whole number of hours that divides a day", s.FrequencyMinutes)
+	}
+	if s.FrequencyMinutes > minutesPerDay && !s.isDaily() {
+		return fmt.Errorf("interval of %d minutes must be a whole number of days", s.FrequencyMinutes)
+	}
+	if _, err := loadLocation(s.Timezone); err != nil {
+		return err
+	}
+	if s.Days != "" {
+		if s.FrequencyMinutes != minutesPerDay || s.DeliveryTime == "" {
+			return fmt.Errorf("delivery days require a daily subscription with a delivery time")
+		}
+		if _, err := ParseDays(s.Days); err != nil {
+			return err
+		}
+	}
+	if s.DeliveryTime == "" {
+		return nil
+	}
+	if !s.isDaily() {
+		return fmt.Errorf("delivery time is only supported for daily subscriptions")
+	}
+	if _, err := time.Parse(deliveryTimeLayout, s.DeliveryTime); err != nil {
+		return fmt.Errorf("invalid delivery time %q: expected HH:MM", s.DeliveryTime)
+	}
+	return nil
+}
+
+// First returns the first notification slot for a newly created subscription.
+func First(s Schedule, now time.Time) (time.Time, error) {
+	if s.DeliveryTime == "" || !s.isDaily() {
+		return now.Add(s.frequency()).UTC(), nil
+	}
+	return s.nextDeliveryTime(now)
+}
+
+// Next returns the slot following previous that is strictly after now.
+// Slots missed while the service was down are skipped, so a single
+// notification is sent per catch-up regardless of how long the outage was.
+func Next(s Schedule, previous, now time.Time) (time.Time, error) {
+	if s.DeliveryTime != "" && s.isDaily() {
+		loc, err := loadLocation(s.Timezone)
+		if err != nil {
+			return time.Time{}, err
+		}
+		// Step by calendar days in the subscriber's timezone rather than by a
+		// fixed duration, so a DST shift neither skips nor repeats a day.
+		local := previous.In(loc)
+		from := time.Date(local.Year(), local.Month(), local.Day()+s.FrequencyMinutes/minutesPerDay, 0, 0, 0, 0, loc).
+			Add(-time.Nanosecond)
+		if from.Before(now) {
+			from = now
+		}
+		return s.nextDeliveryTime(from)
+	}
+
+	next := previous.Add(s.frequency())
+	if !next.After(now) {
+		missed := now.Sub(next)/s.frequency() + 1
+		next = next.Add(missed * s.frequency())
+	}
+	return next.UTC(), nil
+}
+
+// ShouldSend reports whether a due slot should still be delivered under the given policy.
+func ShouldSend(policy CatchUpPolicy, slot, now time.Time, tolerance time.Duration) bool {
+	if policy == CatchUpSkip {
+		return now.Sub(slot) <= tolerance
+	}
+	return true
+}
+
+func (s Schedule) frequency() time.Duration {
+	return time.Duration(s.FrequencyMinutes) * time.Minute
+}
+
+func (s Schedule) isDaily() bool {
+	return s.FrequencyMinutes >= minutesPerDay && s.FrequencyMinutes%minutesPerDay == 0
+}
+
+func (s Schedule) nextDeliveryTime(after time.Time) (time.Time, error) {
+	loc, err := loadLocation(s.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	at, err := time.Parse(deliveryTimeLayout, s.DeliveryTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid delivery time %q: %w", s.DeliveryTime, err)
+	}
+
+	days, err := ParseDays(s.Days)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	local := after.In(loc)
+	candidate := time.Date(local.Year(), local.Month(), local.Day(), at.Hour(), at.Minute(), 0, 0, loc)
+	for !candidate.After(after) || !days[candidate.Weekday()] {
+		candidate = time.Date(candidate.Year(), candidate.Month(), candidate.Day()+1, at.Hour(), at.Minute(), 0, 0, loc)
+	}
+	return candidate.UTC(), nil
+}
+
+// Weekdays is a set of days of the week indexed by time.Weekday.
+type Weekdays [7]bool
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseDays parses a comma-separated list of three-letter day names and
+// ranges, e.g. "Mon-Fri" or "Sat,Sun". Ranges may wrap around the week
+// ("Fri-Mon"). An empty list means every day.
+func ParseDays(spec string) (Weekdays, error) {
+	var days Weekdays
+	if spec == "" {
+		for i := range days {
+			days[i] = true
+		}
+		return days, nil
+	}
+	for _, item := range strings.Split(spec, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(item), "-")
+		first, ok := weekdayNames[strings.ToLower(from)]
+		if !ok {
+			return days, fmt.Errorf("invalid delivery days %q: unknown day %q", spec, from)
+		}
+		last := first
+		if isRange {
+			if last, ok = weekdayNames[strings.ToLower(to)]; !ok {
+				return days, fmt.Errorf("invalid delivery days %q: unknown day %q", spec, to)
+			}
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		name = defaultTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
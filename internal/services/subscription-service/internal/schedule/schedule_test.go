@@ -0,0 +1,215 @@
+package schedule_test
+
+import (
+	"testing"
+	"time"
+
+	"subscription-service/internal/schedule"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNext_Hourly_AdvancesFromPreviousSlot(t *testing.T) {
+	s := schedule.Schedule{FrequencyMinutes: 60, Timezone: "UTC"}
+	previous := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	now := previous.Add(30 * time.Second)
+
+	next, err := schedule.Next(s, previous, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC), next)
+}
+
+func TestNext_Hourly_SkipsSlotsMissedDuringDowntime(t *testing.T) {
+	s := schedule.Schedule{FrequencyMinutes: 60, Timezone: "UTC"}
+	previous := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 6, 1, 14, 20, 0, 0, time.UTC)
+
+	next, err := schedule.Next(s, previous, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 1, 15, 0, 0, 0, time.UTC), next)
+}
+
+func TestNext_Daily_UsesDeliveryTimeInTimezone(t *testing.T) {
+	s := schedule.Schedule{FrequencyMinutes: 1440, DeliveryTime: "07:30", Timezone: "Europe/Kyiv"}
+	previous := time.Date(2025, 6, 1, 4, 30, 0, 0, time.UTC)
+	now := previous.Add(time.Minute)
+
+	next, err := schedule.Next(s, previous, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 2, 4, 30, 0, 0, time.UTC), next)
+}
+
+func TestNext_Daily_AcrossDSTTransitions(t *testing.T) {
+	s := schedule.Schedule{FrequencyMinutes: 1440, DeliveryTime: "08:30", Timezone: "Europe/Kyiv"}
+
+	tests := []struct {
+		name     string
+		previous time.Time
+		want     time.Time
+	}{
+		{
+			// Clocks move forward on 2025-03-30, so the day is 23 hours long.
+			name:     "spring forward",
+			previous: time.Date(2025, 3, 29, 6, 30, 0, 0, time.UTC),
+			want:     time.Date(2025, 3, 30, 5, 30, 0, 0, time.UTC),
+		},
+		{
+			// Clocks move back on 2025-10-26, so the day is 25 hours long.
+			name:     "fall back",
+			previous: time.Date(2025, 10, 25, 5, 30, 0, 0, time.UTC),
+			want:     time.Date(2025, 10, 26, 6, 30, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := schedule.Next(s, tt.previous, tt.previous.Add(time.Minute))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, next)
+		})
+	}
+}
+
+func TestFirst_Daily_PicksNextDeliveryTime(t *testing.T) {
+	s := schedule.Schedule{FrequencyMinutes: 1440, DeliveryTime: "08:00", Timezone: "UTC"}
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	first, err := schedule.First(s, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC), first)
+}
+
+func TestValidate_RejectsDeliveryTimeForHourly(t *testing.T) {
+	err := schedule.Validate(schedule.Schedule{FrequencyMinutes: 60, DeliveryTime: "08:00", Timezone: "UTC"})
+	assert.Error(t, err)
+}
+
+func TestShouldSend(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	stale := now.Add(-time.Hour)
+
+	assert.True(t, schedule.ShouldSend(schedule.CatchUpSendOnce, stale, now, 5*time.Minute))
+	assert.False(t, schedule.ShouldSend(schedule.CatchUpSkip, stale, now, 5*time.Minute))
+	assert.True(t, schedule.ShouldSend(schedule.CatchUpSkip, now.Add(-time.Minute), now, 5*time.Minute))
+}
+
+func TestNext_Weekdays_SkipsWeekendInTimezone(t *testing.T) {
+	s := schedule.Schedule{FrequencyMinutes: 1440, DeliveryTime: "07:30", Timezone: "Europe/Kyiv", Days: "Mon-Fri"}
+	// Friday 2025-06-06 07:30 in Kyiv.
+	previous := time.Date(2025, 6, 6, 4, 30, 0, 0, time.UTC)
+	now := previous.Add(time.Minute)
+
+	next, err := schedule.Next(s, previous, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 9, 4, 30, 0, 0, time.UTC), next)
+}
+
+func TestNext_Days_AcrossDSTTransitions(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule schedule.Schedule
+		previous time.Time
+		want     time.Time
+	}{
+		{
+			// Friday 07:30 EET to Monday 07:30 EEST over the 2025-03-30 shift.
+			name:     "weekdays over spring forward",
+			schedule: schedule.Schedule{FrequencyMinutes: 1440, DeliveryTime: "07:30", Timezone: "Europe/Kyiv", Days: "Mon-Fri"},
+			previous: time.Date(2025, 3, 28, 5, 30, 0, 0, time.UTC),
+			want:     time.Date(2025, 3, 31, 4, 30, 0, 0, time.UTC),
+		},
+		{
+			// Friday 07:30 EEST to Monday 07:30 EET over the 2025-10-26 shift.
+			name:     "weekdays over fall back",
+			schedule: schedule.Schedule{FrequencyMinutes: 1440, DeliveryTime: "07:30", Timezone: "Europe/Kyiv", Days: "Mon-Fri"},
+			previous: time.Date(2025, 10, 24, 4, 30, 0, 0, time.UTC),
+			want:     time.Date(2025, 10, 27, 5, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekend on spring forward day",
+			schedule: schedule.Schedule{FrequencyMinutes: 1440, DeliveryTime: "08:30", Timezone: "Europe/Kyiv", Days: "Sat,Sun"},
+			previous: time.Date(2025, 3, 29, 6, 30, 0, 0, time.UTC),
+			want:     time.Date(2025, 3, 30, 5, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekend on fall back day",
+			schedule: schedule.Schedule{FrequencyMinutes: 1440, DeliveryTime: "08:30", Timezone: "Europe/Kyiv", Days: "Sat,Sun"},
+			previous: time.Date(2025, 10, 25, 5, 30, 0, 0, time.UTC),
+			want:     time.Date(2025, 10, 26, 6, 30, 0, 0, time.UTC),
+		},
+		{
+			// Sunday to the next Sunday, which is 167 hours later.
+			name:     "weekly over spring forward",
+			schedule: schedule.Schedule{FrequencyMinutes: 1440, DeliveryTime: "09:00", Timezone: "Europe/Kyiv", Days: "Sun"},
+			previous: time.Date(2025, 3, 23, 7, 0, 0, 0, time.UTC),
+			want:     time.Date(2025, 3, 30, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			// Sunday to the next Sunday, which is 169 hours later.
+			name:     "weekly over fall back",
+			schedule: schedule.Schedule{FrequencyMinutes: 1440, DeliveryTime: "09:00", Timezone: "Europe/Kyiv", Days: "Sun"},
+			previous: time.Date(2025, 10, 19, 6, 0, 0, 0, time.UTC),
+			want:     time.Date(2025, 10, 26, 7, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := schedule.Next(tt.schedule, tt.previous, tt.previous.Add(time.Minute))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, next)
+		})
+	}
+}
+
+func TestFirst_Days_PicksNextAllowedDay(t *testing.T) {
+	s := schedule.Schedule{FrequencyMinutes: 1440, DeliveryTime: "09:00", Timezone: "UTC", Days: "Sat,Sun"}
+	// Wednesday.
+	now := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+
+	first, err := schedule.First(s, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC), first)
+}
+
+func TestNext_CustomInterval(t *testing.T) {
+	s := schedule.Schedule{FrequencyMinutes: 6 * 60, Timezone: "UTC"}
+	previous := time.Date(2025, 6, 1, 6, 0, 0, 0, time.UTC)
+
+	next, err := schedule.Next(s, previous, previous.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), next)
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		sched   schedule.Schedule
+		wantErr bool
+	}{
+		{"every 3h", schedule.Schedule{FrequencyMinutes: 180}, false},
+		{"every 12h", schedule.Schedule{FrequencyMinutes: 720}, false},
+		{"every 5h", schedule.Schedule{FrequencyMinutes: 300}, true},
+		{"every 90 minutes", schedule.Schedule{FrequencyMinutes: 90}, true},
+		{"every 36h", schedule.Schedule{FrequencyMinutes: 36 * 60}, true},
+		{"weekdays", schedule.Schedule{FrequencyMinutes: 1440, DeliveryTime: "07:30", Days: "Mon-Fri"}, false},
+		{"wrapping range", schedule.Schedule{FrequencyMinutes: 1440, DeliveryTime: "07:30", Days: "fri-mon"}, false},
+		{"days without delivery time", schedule.Schedule{FrequencyMinutes: 1440, Days: "Mon-Fri"}, true},
+		{"days on an interval", schedule.Schedule{FrequencyMinutes: 180, DeliveryTime: "07:30", Days: "Mon"}, true},
+		{"unknown day", schedule.Schedule{FrequencyMinutes: 1440, DeliveryTime: "07:30", Days: "Mon-Fry"}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := schedule.Validate(tc.sched)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseDays(t *testing.T) {
+	days, err := schedule.ParseDays("Fri-Mon,Wed")
+	require.NoError(t, err)
+	assert.Equal(t, schedule.Weekdays{true, true, false, true, false, true, true}, days)
+}
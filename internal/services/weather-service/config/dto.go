@@ -9,6 +9,7 @@ type Config struct {
 	OpenWeather OpenWeatherConfig
 	WeatherAPI  WeatherAPIConfig
 	Redis       RedisConfig
+	Kafka       KafkaConfig
 	Monitoring  MonitoringConfig
 	Health      HealthConfig
 }
@@ -22,11 +23,13 @@ type OpenWeatherConfig struct {
 	APIKey          string `envconfig:"OPENWEATHERMAP_API_KEY" required:"true"`
 	GeocodingAPIURL string `envconfig:"GEOCODING_API_URL" required:"true"`
 	WeatherAPIURL   string `envconfig:"OPENWEATHERMAP_API_URL" required:"true"`
+	ForecastAPIURL  string `envconfig:"OPENWEATHERMAP_FORECAST_API_URL" default:"https://api.openweathermap.org/data/2.5/forecast"`
 }
 
 type WeatherAPIConfig struct {
-	APIKey string `envconfig:"WEATHER_API_KEY" required:"true"`
-	URL    string `envconfig:"WEATHER_API_URL" required:"true" default:"http://api.weatherapi.com/v1/current.json"`
+	APIKey      string `envconfig:"WEATHER_API_KEY" required:"true"`
+	URL         string `envconfig:"WEATHER_API_URL" required:"true" default:"http://api.weatherapi.com/v1/current.json"`
+	ForecastURL string `envconfig:"WEATHER_API_FORECAST_URL" default:"http://api.weatherapi.com/v1/forecast.json"`
 }
 
 type RedisConfig struct {
@@ -37,6 +40,11 @@ type RedisConfig struct {
 	CacheTTL time.Duration `envconfig:"REDIS_CACHE_TTL" default:"10m"`
 }
 
+type KafkaConfig struct {
+	Brokers             []string `envconfig:"KAFKA_BROKERS" required:"true" default:"kafka:9092"`
+	WeatherUpdatedTopic string   `envconfig:"KAFKA_WEATHER_CITY_UPDATED_TOPIC" default:"weather.city_updated"`
+}
+
 type MonitoringConfig struct {
 	RedisExporterPort       int    `envconfig:"REDIS_EXPORTER_PORT" default:"9121"`
 	PrometheusPort          int    `envconfig:"PROMETHEUS_PORT" default:"9090"`
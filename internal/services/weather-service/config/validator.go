@@ -18,12 +18,18 @@ func validate(cfg *Config) error {
 	if cfg.OpenWeather.WeatherAPIURL == "" {
 		return fmt.Errorf("OPENWEATHERMAP_API_URL is required")
 	}
+	if cfg.OpenWeather.ForecastAPIURL == "" {
+		return fmt.Errorf("OPENWEATHERMAP_FORECAST_API_URL is required")
+	}
 	if cfg.WeatherAPI.APIKey == "" {
 		return fmt.Errorf("WEATHER_API_KEY is required")
 	}
 	if cfg.WeatherAPI.URL == "" {
 		return fmt.Errorf("WEATHER_API_URL is required")
 	}
+	if cfg.WeatherAPI.ForecastURL == "" {
+		return fmt.Errorf("WEATHER_API_FORECAST_URL is required")
+	}
 	if cfg.Redis.Host == "" {
 		return fmt.Errorf("REDIS_HOST is required")
 	}
@@ -36,5 +42,11 @@ func validate(cfg *Config) error {
 	if cfg.Redis.CacheTTL < 0 {
 		return fmt.Errorf("redis cache ttl must be > 0")
 	}
+	if len(cfg.Kafka.Brokers) == 0 {
+		return fmt.Errorf("KAFKA_BROKERS is required")
+	}
+	if cfg.Kafka.WeatherUpdatedTopic == "" {
+		return fmt.Errorf("KAFKA_WEATHER_CITY_UPDATED_TOPIC is required")
+	}
 	return nil
 }
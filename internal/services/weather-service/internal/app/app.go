@@ -31,17 +31,34 @@ func Run() error {
 	httpClient := httpclient.New()
 
 	geo := infrastructure.NewGeocodingService(httpClient, cfg.OpenWeather.GeocodingAPIURL, cfg.OpenWeather.APIKey)
-	openWeather := infrastructure.NewOpenWeatherAPI(httpClient, cfg.OpenWeather.WeatherAPIURL, cfg.OpenWeather.APIKey)
+	openWeather := infrastructure.NewOpenWeatherAPI(
+		httpClient,
+		cfg.OpenWeather.WeatherAPIURL,
+		cfg.OpenWeather.ForecastAPIURL,
+		cfg.OpenWeather.APIKey,
+	)
 	openWeatherProvider := provider.NewOpenWeatherProvider(geo, openWeather)
 
-	weatherAPI := infrastructure.NewWeatherAPIProvider(httpClient, cfg.WeatherAPI.URL, cfg.WeatherAPI.APIKey)
+	weatherAPI := infrastructure.NewWeatherAPIProvider(
+		httpClient,
+		cfg.WeatherAPI.URL,
+		cfg.WeatherAPI.ForecastURL,
+		cfg.WeatherAPI.APIKey,
+	)
 	weatherAPIProvider := provider.NewWeatherAPIProvider(weatherAPI)
 
 	openWeatherChain := provider.NewChainWeatherProvider(openWeatherProvider)
 	weatherAPIChain := provider.NewChainWeatherProvider(weatherAPIProvider)
 	weatherAPIChain.SetNext(openWeatherChain)
 
-	cachedProvider := provider.NewCachedWeatherProvider(weatherAPIChain, cache)
+	publisher := infrastructure.NewKafkaPublisher(cfg.Kafka.Brokers, cfg.Kafka.WeatherUpdatedTopic)
+	defer func() {
+		if err := publisher.Close(); err != nil {
+			log.Printf("failed to close publisher: %v", err)
+		}
+	}()
+
+	cachedProvider := provider.NewCachedWeatherProviderWithEvents(weatherAPIChain, cache, publisher)
 
 	address := fmt.Sprintf(":%d", cfg.Server.Port)
 	log.Printf("weather-service starting on %s", address)
@@ -1,13 +1,38 @@
 package domain
 
+import "time"
+
 type Metrics struct {
-	Temperature float64 `json:"temperature"`
-	Humidity    float64 `json:"humidity"`
-	Description string  `json:"description"`
-	City        string  `json:"city"`
+	Temperature float64   `json:"temperature"`
+	Humidity    float64   `json:"humidity"`
+	Description string    `json:"description"`
+	City        string    `json:"city"`
+	Provider    string    `json:"provider,omitempty"`
+	FetchedAt   time.Time `json:"fetched_at,omitempty"`
 }
 
 type Coordinates struct {
 	Lat float64 `json:"lat"`
 	Lon float64 `json:"lon"`
 }
+
+type Forecast struct {
+	City   string           `json:"city"`
+	Daily  []DailyForecast  `json:"daily"`
+	Hourly []HourlyForecast `json:"hourly"`
+}
+
+type DailyForecast struct {
+	Date           string  `json:"date"`
+	Description    string  `json:"description"`
+	MinTemperature float64 `json:"min_temperature"`
+	MaxTemperature float64 `json:"max_temperature"`
+	Humidity       float64 `json:"humidity"`
+}
+
+type HourlyForecast struct {
+	Time        int64   `json:"time"`
+	Description string  `json:"description"`
+	Temperature float64 `json:"temperature"`
+	Humidity    float64 `json:"humidity"`
+}
@@ -88,6 +88,36 @@ func (r *RedisCache) Delete(ctx context.Context, city string) error {
 	return nil
 }
 
+func (r *RedisCache) GetForecast(ctx context.Context, city string, days int) (*domain.Forecast, error) {
+	key := r.buildForecastKey(city, days)
+	data, err := r.client.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get forecast from cache: %w", err)
+	}
+
+	var forecast domain.Forecast
+	if err := json.Unmarshal([]byte(data), &forecast); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cached forecast: %w", err)
+	}
+
+	return &forecast, nil
+}
+
+func (r *RedisCache) SetForecast(ctx context.Context, city string, days int, forecast domain.Forecast) error {
+	key := r.buildForecastKey(city, days)
+	data, err := json.Marshal(forecast)
+	if err != nil {
+		return fmt.Errorf("failed to marshal forecast: %w", err)
+	}
+	if err := r.client.Set(ctx, key, data, r.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set forecast cache: %w", err)
+	}
+	return nil
+}
+
 func (r *RedisCache) Close() error {
 	return r.client.Close()
 }
@@ -96,6 +126,10 @@ func (r *RedisCache) buildKey(city string) string {
 	return fmt.Sprintf("weather:%s", city)
 }
 
+func (r *RedisCache) buildForecastKey(city string, days int) string {
+	return fmt.Sprintf("forecast:%s:%d", city, days)
+}
+
 func (r *RedisCache) GetDefaultTTL() time.Duration {
 	return r.ttl
 }
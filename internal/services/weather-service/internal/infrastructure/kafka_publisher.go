@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"internal/services/weather-service/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	weatherUpdatedEventType = "weather.city_updated"
+	eventSource             = "weather-service"
+	publishTimeout          = 3 * time.Second
+)
+
+type messageWriterManager interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+type WeatherUpdatedEvent struct {
+	EventID   string         `json:"event_id"`
+	EventType string         `json:"event_type"`
+	Source    string         `json:"source"`
+	City      string         `json:"city"`
+	Metrics   domain.Metrics `json:"metrics"`
+	Provider  string         `json:"provider"`
+	FetchedAt int64          `json:"fetched_at"`
+	UpdatedAt int64          `json:"updated_at"`
+}
+
+type KafkaPublisher struct {
+	writer messageWriterManager
+}
+
+func NewKafkaPublisher(brokers []string, topic string) *KafkaPublisher {
+	return &KafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Topic:    topic,
+			Balancer: &kafka.Hash{},
+		},
+	}
+}
+
+func (p *KafkaPublisher) PublishWeatherUpdated(city string, metrics domain.Metrics) error {
+	event := WeatherUpdatedEvent{
+		EventID:   uuid.NewString(),
+		EventType: weatherUpdatedEventType,
+		Source:    eventSource,
+		City:      city,
+		Metrics:   metrics,
+		Provider:  metrics.Provider,
+		FetchedAt: metrics.FetchedAt.Unix(),
+		UpdatedAt: time.Now().Unix(),
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal weather updated event: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	if err := p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(city),
+		Value: payload,
+		Time:  time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to publish weather updated event: %w", err)
+	}
+	return nil
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"time"
 
 	"internal/services/weather-service/internal/domain"
 )
@@ -63,17 +64,26 @@ func (g *GeocodingService) GetCoordinates(ctx context.Context, city string) (dom
 	return geo[0], nil
 }
 
+const (
+	openWeatherProviderName = "openweathermap"
+	forecastStepsPerDay     = 8
+	forecastDateLayout      = "2006-01-02"
+	middayHour              = 12
+)
+
 type OpenWeatherAPI struct {
-	httpClient httpClientManager
-	apiurl     string
-	apikey     string
+	httpClient  httpClientManager
+	apiurl      string
+	forecastURL string
+	apikey      string
 }
 
-func NewOpenWeatherAPI(httpClient httpClientManager, apiurl, apikey string) *OpenWeatherAPI {
+func NewOpenWeatherAPI(httpClient httpClientManager, apiurl, forecastURL, apikey string) *OpenWeatherAPI {
 	return &OpenWeatherAPI{
-		httpClient: httpClient,
-		apiurl:     apiurl,
-		apikey:     apikey,
+		httpClient:  httpClient,
+		apiurl:      apiurl,
+		forecastURL: forecastURL,
+		apikey:      apikey,
 	}
 }
 
@@ -123,5 +133,118 @@ func (w *OpenWeatherAPI) GetWeather(ctx context.Context, coords domain.Coordinat
 		Humidity:    data.Main.Humidity,
 		Description: data.Weather[0].Description,
 		City:        data.Name,
+		Provider:    openWeatherProviderName,
 	}, nil
 }
+
+func (w *OpenWeatherAPI) GetForecast(ctx context.Context, coords domain.Coordinates, days int) (domain.Forecast, error) {
+	forecastURL := fmt.Sprintf("%s?lat=%f&lon=%f&cnt=%d&appid=%s&units=metric",
+		w.forecastURL, coords.Lat, coords.Lon, days*forecastStepsPerDay, w.apikey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, forecastURL, http.NoBody)
+	if err != nil {
+		return domain.Forecast{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return domain.Forecast{}, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.Forecast{}, fmt.Errorf("API returned status code: %d", resp.StatusCode)
+	}
+
+	var data struct {
+		List []struct {
+			Dt   int64 `json:"dt"`
+			Main struct {
+				Temperature    float64 `json:"temp"`
+				MinTemperature float64 `json:"temp_min"`
+				MaxTemperature float64 `json:"temp_max"`
+				Humidity       float64 `json:"humidity"`
+			} `json:"main"`
+			Weather []struct {
+				Description string `json:"description"`
+			} `json:"weather"`
+		} `json:"list"`
+		City struct {
+			Name     string `json:"name"`
+			Timezone int    `json:"timezone"`
+		} `json:"city"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return domain.Forecast{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(data.List) == 0 {
+		return domain.Forecast{}, fmt.Errorf("no forecast data available")
+	}
+
+	location := time.FixedZone(data.City.Name, data.City.Timezone)
+	forecast := domain.Forecast{City: data.City.Name}
+	dayIndex := make(map[string]int)
+	var humidityCount []int
+	var middayDistance []int
+
+	for _, item := range data.List {
+		description := ""
+		if len(item.Weather) > 0 {
+			description = item.Weather[0].Description
+		}
+		forecast.Hourly = append(forecast.Hourly, domain.HourlyForecast{
+			Time:        item.Dt,
+			Description: description,
+			Temperature: item.Main.Temperature,
+			Humidity:    item.Main.Humidity,
+		})
+
+		localTime := time.Unix(item.Dt, 0).In(location)
+		date := localTime.Format(forecastDateLayout)
+		distance := abs(localTime.Hour() - middayHour)
+
+		i, ok := dayIndex[date]
+		if !ok {
+			dayIndex[date] = len(forecast.Daily)
+			forecast.Daily = append(forecast.Daily, domain.DailyForecast{
+				Date:           date,
+				Description:    description,
+				MinTemperature: item.Main.MinTemperature,
+				MaxTemperature: item.Main.MaxTemperature,
+				Humidity:       item.Main.Humidity,
+			})
+			humidityCount = append(humidityCount, 1)
+			middayDistance = append(middayDistance, distance)
+			continue
+		}
+
+		day := &forecast.Daily[i]
+		day.MinTemperature = min(day.MinTemperature, item.Main.MinTemperature)
+		day.MaxTemperature = max(day.MaxTemperature, item.Main.MaxTemperature)
+		day.Humidity = (day.Humidity*float64(humidityCount[i]) + item.Main.Humidity) / float64(humidityCount[i]+1)
+		humidityCount[i]++
+		if distance < middayDistance[i] {
+			day.Description = description
+			middayDistance[i] = distance
+		}
+	}
+
+	if len(forecast.Daily) > days {
+		forecast.Daily = forecast.Daily[:days]
+	}
+
+	return forecast, nil
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
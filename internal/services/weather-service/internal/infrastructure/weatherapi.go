@@ -11,17 +11,21 @@ import (
 	"internal/services/weather-service/internal/domain"
 )
 
+const weatherAPIProviderName = "weatherapi"
+
 type WeatherAPIProvider struct {
-	httpClient httpClientManager
-	apiurl     string
-	apikey     string
+	httpClient  httpClientManager
+	apiurl      string
+	forecastURL string
+	apikey      string
 }
 
-func NewWeatherAPIProvider(httpClient httpClientManager, apiurl, apikey string) *WeatherAPIProvider {
+func NewWeatherAPIProvider(httpClient httpClientManager, apiurl, forecastURL, apikey string) *WeatherAPIProvider {
 	return &WeatherAPIProvider{
-		httpClient: httpClient,
-		apiurl:     apiurl,
-		apikey:     apikey,
+		httpClient:  httpClient,
+		apiurl:      apiurl,
+		forecastURL: forecastURL,
+		apikey:      apikey,
 	}
 }
 
@@ -69,5 +73,85 @@ func (w *WeatherAPIProvider) GetWeather(ctx context.Context, city string) (domai
 		Humidity:    data.Current.Humidity,
 		Description: data.Current.Condition.Text,
 		City:        data.Location.Name,
+		Provider:    weatherAPIProviderName,
 	}, nil
 }
+
+func (w *WeatherAPIProvider) GetForecast(ctx context.Context, city string, days int) (domain.Forecast, error) {
+	forecastURL := fmt.Sprintf("%s?key=%s&q=%s&days=%d", w.forecastURL, w.apikey, url.QueryEscape(city), days)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, forecastURL, http.NoBody)
+	if err != nil {
+		return domain.Forecast{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return domain.Forecast{}, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.Forecast{}, fmt.Errorf("API returned status code: %d", resp.StatusCode)
+	}
+
+	var data struct {
+		Location struct {
+			Name string `json:"name"`
+		} `json:"location"`
+		Forecast struct {
+			ForecastDay []struct {
+				Date string `json:"date"`
+				Day  struct {
+					MaxTempC    float64 `json:"maxtemp_c"`
+					MinTempC    float64 `json:"mintemp_c"`
+					AvgHumidity float64 `json:"avghumidity"`
+					Condition   struct {
+						Text string `json:"text"`
+					} `json:"condition"`
+				} `json:"day"`
+				Hour []struct {
+					TimeEpoch int64   `json:"time_epoch"`
+					TempC     float64 `json:"temp_c"`
+					Humidity  float64 `json:"humidity"`
+					Condition struct {
+						Text string `json:"text"`
+					} `json:"condition"`
+				} `json:"hour"`
+			} `json:"forecastday"`
+		} `json:"forecast"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return domain.Forecast{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(data.Forecast.ForecastDay) == 0 {
+		return domain.Forecast{}, fmt.Errorf("no forecast data available")
+	}
+
+	forecast := domain.Forecast{City: data.Location.Name}
+	for _, day := range data.Forecast.ForecastDay {
+		forecast.Daily = append(forecast.Daily, domain.DailyForecast{
+			Date:           day.Date,
+			Description:    day.Day.Condition.Text,
+			MinTemperature: day.Day.MinTempC,
+			MaxTemperature: day.Day.MaxTempC,
+			Humidity:       day.Day.AvgHumidity,
+		})
+		for _, hour := range day.Hour {
+			forecast.Hourly = append(forecast.Hourly, domain.HourlyForecast{
+				Time:        hour.TimeEpoch,
+				Description: hour.Condition.Text,
+				Temperature: hour.TempC,
+				Humidity:    hour.Humidity,
+			})
+		}
+	}
+
+	return forecast, nil
+}
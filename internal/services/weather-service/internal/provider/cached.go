@@ -14,6 +14,8 @@ type weatherCacheManager interface {
 	Get(ctx context.Context, city string) (*domain.Metrics, error)
 	Set(ctx context.Context, city string, metrics domain.Metrics) error
 	Delete(ctx context.Context, city string) error
+	GetForecast(ctx context.Context, city string, days int) (*domain.Forecast, error)
+	SetForecast(ctx context.Context, city string, days int, forecast domain.Forecast) error
 	Close() error
 }
 
@@ -61,6 +63,7 @@ func (c *CachedWeatherProvider) GetWeatherByCity(ctx context.Context, city strin
 	if err != nil {
 		return domain.Metrics{}, fmt.Errorf("failed to get weather from provider: %w", err)
 	}
+	metrics.FetchedAt = time.Now().UTC()
 
 	c.wg.Add(1)
 	go func() {
@@ -79,6 +82,34 @@ func (c *CachedWeatherProvider) GetWeatherByCity(ctx context.Context, city strin
 	return metrics, nil
 }
 
+func (c *CachedWeatherProvider) GetForecastByCity(ctx context.Context, city string, days int) (domain.Forecast, error) {
+	cachedForecast, err := c.cache.GetForecast(ctx, city, days)
+	if err != nil {
+		log.Printf("Forecast cache get error for city %s: %v", city, err)
+	} else if cachedForecast != nil {
+		log.Printf("Forecast cache hit for city: %s", city)
+		return *cachedForecast, nil
+	}
+
+	log.Printf("Forecast cache miss for city: %s, fetching from provider", city)
+	forecast, err := c.provider.GetForecastByCity(ctx, city, days)
+	if err != nil {
+		return domain.Forecast{}, fmt.Errorf("failed to get forecast from provider: %w", err)
+	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := c.cache.SetForecast(ctx, city, days, forecast); err != nil {
+			log.Printf("Failed to cache forecast data for city %s: %v", city, err)
+		}
+	}()
+
+	return forecast, nil
+}
+
 func (c *CachedWeatherProvider) Close() error {
 	c.wg.Wait()
 	return c.cache.Close()
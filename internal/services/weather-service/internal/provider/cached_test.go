@@ -9,8 +9,10 @@ import (
 )
 
 type mockCache struct {
-	metrics *domain.Metrics
-	hit     bool
+	metrics     *domain.Metrics
+	hit         bool
+	forecast    *domain.Forecast
+	forecastHit bool
 }
 
 func (m *mockCache) Get(ctx context.Context, city string) (*domain.Metrics, error) {
@@ -27,14 +29,31 @@ func (m *mockCache) Set(ctx context.Context, city string, metrics domain.Metrics
 func (m *mockCache) Delete(ctx context.Context, city string) error { return nil }
 func (m *mockCache) Close() error                                  { return nil }
 
+func (m *mockCache) GetForecast(ctx context.Context, city string, days int) (*domain.Forecast, error) {
+	if m.forecastHit {
+		return m.forecast, nil
+	}
+	return nil, nil
+}
+func (m *mockCache) SetForecast(ctx context.Context, city string, days int, forecast domain.Forecast) error {
+	m.forecast = &forecast
+	m.forecastHit = true
+	return nil
+}
+
 type mockWeatherProviderCached struct {
-	metrics domain.Metrics
+	metrics  domain.Metrics
+	forecast domain.Forecast
 }
 
 func (m *mockWeatherProviderCached) GetWeatherByCity(ctx context.Context, city string) (domain.Metrics, error) {
 	return m.metrics, nil
 }
 
+func (m *mockWeatherProviderCached) GetForecastByCity(ctx context.Context, city string, days int) (domain.Forecast, error) {
+	return m.forecast, nil
+}
+
 func TestCachedWeatherProvider_CacheHit(t *testing.T) {
 	cache := &mockCache{metrics: &domain.Metrics{City: "Kyiv"}, hit: true}
 	prov := &mockWeatherProviderCached{metrics: domain.Metrics{City: "Kyiv"}}
@@ -63,3 +82,61 @@ func TestCachedWeatherProvider_CacheMiss(t *testing.T) {
 		t.Errorf("expected cache to be set after miss, but cache.hit is false")
 	}
 }
+
+func TestCachedWeatherProvider_ForecastCacheHit(t *testing.T) {
+	cache := &mockCache{forecast: &domain.Forecast{City: "Kyiv"}, forecastHit: true}
+	prov := &mockWeatherProviderCached{forecast: domain.Forecast{City: "Other"}}
+	cached := provider.NewCachedWeatherProvider(prov, cache)
+
+	result, err := cached.GetForecastByCity(context.Background(), "Kyiv", 3)
+	if err != nil || result.City != "Kyiv" {
+		t.Errorf("expected forecast cache hit, got: %+v, err: %v", result, err)
+	}
+}
+
+func TestCachedWeatherProvider_ForecastCacheMiss(t *testing.T) {
+	cache := &mockCache{}
+	prov := &mockWeatherProviderCached{forecast: domain.Forecast{City: "Kyiv"}}
+	cached := provider.NewCachedWeatherProvider(prov, cache)
+
+	result, err := cached.GetForecastByCity(context.Background(), "Kyiv", 3)
+	if err != nil || result.City != "Kyiv" {
+		t.Errorf("expected forecast cache miss logic, got: %+v, err: %v", result, err)
+	}
+	if err := cached.Close(); err != nil {
+		t.Errorf("failed to close cache: %v", err)
+	}
+	if !cache.forecastHit || cache.hit {
+		t.Errorf("expected only forecast cache to be set, forecastHit=%v, hit=%v", cache.forecastHit, cache.hit)
+	}
+}
+
+type mockEventPublisher struct {
+	published []domain.Metrics
+}
+
+func (m *mockEventPublisher) PublishWeatherUpdated(city string, metrics domain.Metrics) error {
+	m.published = append(m.published, metrics)
+	return nil
+}
+
+func TestCachedWeatherProvider_CacheMiss_PublishesEvent(t *testing.T) {
+	cache := &mockCache{hit: false}
+	prov := &mockWeatherProviderCached{metrics: domain.Metrics{City: "Kyiv", Provider: "weatherapi"}}
+	publisher := &mockEventPublisher{}
+	cached := provider.NewCachedWeatherProviderWithEvents(prov, cache, publisher)
+
+	if _, err := cached.GetWeatherByCity(context.Background(), "Kyiv"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cached.Close(); err != nil {
+		t.Errorf("failed to close cache: %v", err)
+	}
+	if len(publisher.published) != 1 {
+		t.Fatalf("expected one published event, got %d", len(publisher.published))
+	}
+	event := publisher.published[0]
+	if event.Provider != "weatherapi" || event.FetchedAt.IsZero() {
+		t.Errorf("expected provider and fetch timestamp in event, got: %+v", event)
+	}
+}
@@ -10,10 +10,12 @@ import (
 
 type weatherProviderManager interface {
 	GetWeatherByCity(ctx context.Context, city string) (domain.Metrics, error)
+	GetForecastByCity(ctx context.Context, city string, days int) (domain.Forecast, error)
 }
 
 type WeatherChainHandler interface {
 	GetWeatherByCity(ctx context.Context, city string) (domain.Metrics, error)
+	GetForecastByCity(ctx context.Context, city string, days int) (domain.Forecast, error)
 	SetNext(next WeatherChainHandler)
 }
 
@@ -46,3 +48,18 @@ func (c *ChainWeatherProvider) GetWeatherByCity(ctx context.Context, city string
 
 	return domain.Metrics{}, fmt.Errorf("no fallback provider: %w", err)
 }
+
+func (c *ChainWeatherProvider) GetForecastByCity(ctx context.Context, city string, days int) (domain.Forecast, error) {
+	forecast, err := c.provider.GetForecastByCity(ctx, city, days)
+	if err == nil {
+		return forecast, nil
+	}
+
+	log.Printf("Forecast provider failed: %v, trying next provider", err)
+
+	if c.next != nil {
+		return c.next.GetForecastByCity(ctx, city, days)
+	}
+
+	return domain.Forecast{}, fmt.Errorf("no fallback provider: %w", err)
+}
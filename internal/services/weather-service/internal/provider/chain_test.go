@@ -10,9 +10,10 @@ import (
 )
 
 type mockWeatherProvider struct {
-	metrics domain.Metrics
-	err     error
-	called  *bool
+	metrics  domain.Metrics
+	forecast domain.Forecast
+	err      error
+	called   *bool
 }
 
 func (m *mockWeatherProvider) GetWeatherByCity(ctx context.Context, city string) (domain.Metrics, error) {
@@ -22,6 +23,13 @@ func (m *mockWeatherProvider) GetWeatherByCity(ctx context.Context, city string)
 	return m.metrics, m.err
 }
 
+func (m *mockWeatherProvider) GetForecastByCity(ctx context.Context, city string, days int) (domain.Forecast, error) {
+	if m.called != nil {
+		*m.called = true
+	}
+	return m.forecast, m.err
+}
+
 func TestChainWeatherProvider_FirstFails_SecondUsed(t *testing.T) {
 	firstCalled, secondCalled := false, false
 	first := &mockWeatherProvider{err: errors.New("fail"), called: &firstCalled}
@@ -55,3 +63,30 @@ func TestChainWeatherProvider_FirstSuccess_SecondNotUsed(t *testing.T) {
 		t.Errorf("chain logic failed: %+v, firstCalled=%v, secondCalled=%v", result, firstCalled, secondCalled)
 	}
 }
+
+func TestChainWeatherProvider_Forecast_FirstFails_SecondUsed(t *testing.T) {
+	firstCalled, secondCalled := false, false
+	first := &mockWeatherProvider{err: errors.New("fail"), called: &firstCalled}
+	second := &mockWeatherProvider{forecast: domain.Forecast{City: "Kyiv"}, called: &secondCalled}
+	chain1 := provider.NewChainWeatherProvider(first)
+	chain2 := provider.NewChainWeatherProvider(second)
+	chain1.SetNext(chain2)
+
+	result, err := chain1.GetForecastByCity(context.Background(), "Kyiv", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.City != "Kyiv" || !firstCalled || !secondCalled {
+		t.Errorf("chain logic failed: %+v, firstCalled=%v, secondCalled=%v", result, firstCalled, secondCalled)
+	}
+}
+
+func TestChainWeatherProvider_Forecast_AllFail(t *testing.T) {
+	first := &mockWeatherProvider{err: errors.New("fail")}
+	chain := provider.NewChainWeatherProvider(first)
+
+	_, err := chain.GetForecastByCity(context.Background(), "Kyiv", 2)
+	if err == nil {
+		t.Fatal("expected error when no fallback provider is configured")
+	}
+}
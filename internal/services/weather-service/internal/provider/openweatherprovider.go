@@ -11,6 +11,7 @@ type geocodingManager interface {
 
 type weatherManager interface {
 	GetWeather(ctx context.Context, coords domain.Coordinates) (domain.Metrics, error)
+	GetForecast(ctx context.Context, coords domain.Coordinates, days int) (domain.Forecast, error)
 }
 
 type OpenWeatherProvider struct {
@@ -32,3 +33,11 @@ func (wp *OpenWeatherProvider) GetWeatherByCity(ctx context.Context, city string
 	}
 	return wp.openWeatherAPI.GetWeather(ctx, coords)
 }
+
+func (wp *OpenWeatherProvider) GetForecastByCity(ctx context.Context, city string, days int) (domain.Forecast, error) {
+	coords, err := wp.geocoding.GetCoordinates(ctx, city)
+	if err != nil {
+		return domain.Forecast{}, err
+	}
+	return wp.openWeatherAPI.GetForecast(ctx, coords, days)
+}
@@ -19,14 +19,19 @@ func (m *mockGeocodingManager) GetCoordinates(ctx context.Context, city string)
 }
 
 type mockWeatherManager struct {
-	metrics domain.Metrics
-	err     error
+	metrics  domain.Metrics
+	forecast domain.Forecast
+	err      error
 }
 
 func (m *mockWeatherManager) GetWeather(ctx context.Context, coords domain.Coordinates) (domain.Metrics, error) {
 	return m.metrics, m.err
 }
 
+func (m *mockWeatherManager) GetForecast(ctx context.Context, coords domain.Coordinates, days int) (domain.Forecast, error) {
+	return m.forecast, m.err
+}
+
 func TestOpenWeatherProvider_GetWeatherByCity_Success(t *testing.T) {
 	geo := &mockGeocodingManager{
 		coords: domain.Coordinates{Lat: 50.45, Lon: 30.52},
@@ -77,3 +82,37 @@ func TestOpenWeatherProvider_GetWeatherByCity_WeatherError(t *testing.T) {
 		t.Errorf("expected weather error, got: %v", err)
 	}
 }
+
+func TestOpenWeatherProvider_GetForecastByCity_Success(t *testing.T) {
+	geo := &mockGeocodingManager{
+		coords: domain.Coordinates{Lat: 50.45, Lon: 30.52},
+	}
+	weather := &mockWeatherManager{
+		forecast: domain.Forecast{
+			City:  "Kyiv",
+			Daily: []domain.DailyForecast{{Date: "2025-06-01", MinTemperature: 12, MaxTemperature: 24}},
+		},
+	}
+	providerInstance := provider.NewOpenWeatherProvider(geo, weather)
+
+	result, err := providerInstance.GetForecastByCity(context.Background(), "Kyiv", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.City != "Kyiv" || len(result.Daily) != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestOpenWeatherProvider_GetForecastByCity_GeocodingError(t *testing.T) {
+	geo := &mockGeocodingManager{
+		err: errors.New("geocoding failed"),
+	}
+	weather := &mockWeatherManager{}
+	providerInstance := provider.NewOpenWeatherProvider(geo, weather)
+
+	_, err := providerInstance.GetForecastByCity(context.Background(), "UnknownCity", 1)
+	if err == nil || err.Error() != "geocoding failed" {
+		t.Errorf("expected geocoding error, got: %v", err)
+	}
+}
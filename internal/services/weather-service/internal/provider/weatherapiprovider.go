@@ -7,6 +7,7 @@ import (
 
 type weatherAPIManager interface {
 	GetWeather(ctx context.Context, city string) (domain.Metrics, error)
+	GetForecast(ctx context.Context, city string, days int) (domain.Forecast, error)
 }
 
 type WeatherAPIProvider struct {
@@ -22,3 +23,7 @@ func NewWeatherAPIProvider(weatherapi weatherAPIManager) *WeatherAPIProvider {
 func (wp *WeatherAPIProvider) GetWeatherByCity(ctx context.Context, city string) (domain.Metrics, error) {
 	return wp.weatherapi.GetWeather(ctx, city)
 }
+
+func (wp *WeatherAPIProvider) GetForecastByCity(ctx context.Context, city string, days int) (domain.Forecast, error) {
+	return wp.weatherapi.GetForecast(ctx, city, days)
+}
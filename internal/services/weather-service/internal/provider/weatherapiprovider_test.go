@@ -10,14 +10,19 @@ import (
 )
 
 type mockWeatherAPI struct {
-	metrics domain.Metrics
-	err     error
+	metrics  domain.Metrics
+	forecast domain.Forecast
+	err      error
 }
 
 func (m *mockWeatherAPI) GetWeather(ctx context.Context, city string) (domain.Metrics, error) {
 	return m.metrics, m.err
 }
 
+func (m *mockWeatherAPI) GetForecast(ctx context.Context, city string, days int) (domain.Forecast, error) {
+	return m.forecast, m.err
+}
+
 func TestWeatherAPIProvider_Success(t *testing.T) {
 	weather := &mockWeatherAPI{metrics: domain.Metrics{City: "Kyiv"}}
 	prov := provider.NewWeatherAPIProvider(weather)
@@ -37,3 +42,13 @@ func TestWeatherAPIProvider_Error(t *testing.T) {
 		t.Errorf("expected api error, got: %v", err)
 	}
 }
+
+func TestWeatherAPIProvider_Forecast_Success(t *testing.T) {
+	weather := &mockWeatherAPI{forecast: domain.Forecast{City: "Kyiv"}}
+	prov := provider.NewWeatherAPIProvider(weather)
+
+	result, err := prov.GetForecastByCity(context.Background(), "Kyiv", 3)
+	if err != nil || result.City != "Kyiv" {
+		t.Errorf("expected success, got: %+v, err: %v", result, err)
+	}
+}
@@ -11,6 +11,11 @@ import (
 	"google.golang.org/grpc"
 )
 
+const (
+	defaultForecastDays = 1
+	maxForecastDays     = 5
+)
+
 type WeatherGRPCServer struct {
 	proto.UnimplementedWeatherServiceServer
 	provider *provider.CachedWeatherProvider
@@ -36,6 +41,47 @@ func (s *WeatherGRPCServer) GetWeather(ctx context.Context, req *proto.WeatherRe
 	}, nil
 }
 
+func (s *WeatherGRPCServer) GetForecast(ctx context.Context, req *proto.ForecastRequest) (*proto.ForecastResponse, error) {
+	if req.GetCity() == "" {
+		return nil, fmt.Errorf("city is required")
+	}
+	days := int(req.GetDays())
+	if days == 0 {
+		days = defaultForecastDays
+	}
+	if days < 0 || days > maxForecastDays {
+		return nil, fmt.Errorf("days must be between 1 and %d", maxForecastDays)
+	}
+	forecast, err := s.provider.GetForecastByCity(ctx, req.GetCity(), days)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.ForecastResponse{
+		City:   forecast.City,
+		Daily:  make([]*proto.DailyForecast, 0, len(forecast.Daily)),
+		Hourly: make([]*proto.HourlyForecast, 0, len(forecast.Hourly)),
+	}
+	for _, day := range forecast.Daily {
+		resp.Daily = append(resp.Daily, &proto.DailyForecast{
+			Date:           day.Date,
+			Description:    day.Description,
+			MinTemperature: day.MinTemperature,
+			MaxTemperature: day.MaxTemperature,
+			Humidity:       day.Humidity,
+		})
+	}
+	for _, hour := range forecast.Hourly {
+		resp.Hourly = append(resp.Hourly, &proto.HourlyForecast{
+			Time:        hour.Time,
+			Description: hour.Description,
+			Temperature: hour.Temperature,
+			Humidity:    hour.Humidity,
+		})
+	}
+	return resp, nil
+}
+
 func RunGRPCServer(address string, provider *provider.CachedWeatherProvider) error {
 	var lc net.ListenConfig
 	lis, err := lc.Listen(context.Background(), "tcp", address)
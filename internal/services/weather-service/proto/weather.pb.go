@@ -133,6 +133,262 @@ func (x *WeatherResponse) GetHumidity() float64 {
 	return 0
 }
 
+type ForecastRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Days          int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForecastRequest) Reset() {
+	*x = ForecastRequest{}
+	mi := &file_weather_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastRequest) ProtoMessage() {}
+
+func (x *ForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastRequest.ProtoReflect.Descriptor instead.
+func (*ForecastRequest) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ForecastRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *ForecastRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type DailyForecast struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Date           string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	MinTemperature float64                `protobuf:"fixed64,3,opt,name=min_temperature,json=minTemperature,proto3" json:"min_temperature,omitempty"`
+	MaxTemperature float64                `protobuf:"fixed64,4,opt,name=max_temperature,json=maxTemperature,proto3" json:"max_temperature,omitempty"`
+	Humidity       float64                `protobuf:"fixed64,5,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DailyForecast) Reset() {
+	*x = DailyForecast{}
+	mi := &file_weather_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyForecast) ProtoMessage() {}
+
+func (x *DailyForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyForecast.ProtoReflect.Descriptor instead.
+func (*DailyForecast) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DailyForecast) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DailyForecast) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *DailyForecast) GetMinTemperature() float64 {
+	if x != nil {
+		return x.MinTemperature
+	}
+	return 0
+}
+
+func (x *DailyForecast) GetMaxTemperature() float64 {
+	if x != nil {
+		return x.MaxTemperature
+	}
+	return 0
+}
+
+func (x *DailyForecast) GetHumidity() float64 {
+	if x != nil {
+		return x.Humidity
+	}
+	return 0
+}
+
+type HourlyForecast struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          int64                  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Temperature   float64                `protobuf:"fixed64,3,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	Humidity      float64                `protobuf:"fixed64,4,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HourlyForecast) Reset() {
+	*x = HourlyForecast{}
+	mi := &file_weather_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HourlyForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HourlyForecast) ProtoMessage() {}
+
+func (x *HourlyForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HourlyForecast.ProtoReflect.Descriptor instead.
+func (*HourlyForecast) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HourlyForecast) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *HourlyForecast) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *HourlyForecast) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *HourlyForecast) GetHumidity() float64 {
+	if x != nil {
+		return x.Humidity
+	}
+	return 0
+}
+
+type ForecastResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Daily         []*DailyForecast       `protobuf:"bytes,2,rep,name=daily,proto3" json:"daily,omitempty"`
+	Hourly        []*HourlyForecast      `protobuf:"bytes,3,rep,name=hourly,proto3" json:"hourly,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForecastResponse) Reset() {
+	*x = ForecastResponse{}
+	mi := &file_weather_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastResponse) ProtoMessage() {}
+
+func (x *ForecastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastResponse.ProtoReflect.Descriptor instead.
+func (*ForecastResponse) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ForecastResponse) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *ForecastResponse) GetDaily() []*DailyForecast {
+	if x != nil {
+		return x.Daily
+	}
+	return nil
+}
+
+func (x *ForecastResponse) GetHourly() []*HourlyForecast {
+	if x != nil {
+		return x.Hourly
+	}
+	return nil
+}
+
 var File_weather_proto protoreflect.FileDescriptor
 
 const file_weather_proto_rawDesc = "" +
@@ -144,10 +400,29 @@ const file_weather_proto_rawDesc = "" +
 	"\x04city\x18\x01 \x01(\tR\x04city\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12 \n" +
 	"\vtemperature\x18\x03 \x01(\x01R\vtemperature\x12\x1a\n" +
-	"\bhumidity\x18\x04 \x01(\x01R\bhumidity2Q\n" +
+	"\bhumidity\x18\x04 \x01(\x01R\bhumidity\"9\n" +
+	"\x0fForecastRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\"\xb3\x01\n" +
+	"\rDailyForecast\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12'\n" +
+	"\x0fmin_temperature\x18\x03 \x01(\x01R\x0eminTemperature\x12'\n" +
+	"\x0fmax_temperature\x18\x04 \x01(\x01R\x0emaxTemperature\x12\x1a\n" +
+	"\bhumidity\x18\x05 \x01(\x01R\bhumidity\"\x84\x01\n" +
+	"\x0eHourlyForecast\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x03R\x04time\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12 \n" +
+	"\vtemperature\x18\x03 \x01(\x01R\vtemperature\x12\x1a\n" +
+	"\bhumidity\x18\x04 \x01(\x01R\bhumidity\"\x85\x01\n" +
+	"\x10ForecastResponse\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12,\n" +
+	"\x05daily\x18\x02 \x03(\v2\x16.weather.DailyForecastR\x05daily\x12/\n" +
+	"\x06hourly\x18\x03 \x03(\v2\x17.weather.HourlyForecastR\x06hourly2\x95\x01\n" +
 	"\x0eWeatherService\x12?\n" +
 	"\n" +
-	"GetWeather\x12\x17.weather.WeatherRequest\x1a\x18.weather.WeatherResponseB)Z'internal/services/weather-service/protob\x06proto3"
+	"GetWeather\x12\x17.weather.WeatherRequest\x1a\x18.weather.WeatherResponse\x12B\n" +
+	"\vGetForecast\x12\x18.weather.ForecastRequest\x1a\x19.weather.ForecastResponseB2Z0internal/services/weather-service/internal/protob\x06proto3"
 
 var (
 	file_weather_proto_rawDescOnce sync.Once
@@ -161,19 +436,27 @@ func file_weather_proto_rawDescGZIP() []byte {
 	return file_weather_proto_rawDescData
 }
 
-var file_weather_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_weather_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_weather_proto_goTypes = []any{
-	(*WeatherRequest)(nil),  // 0: weather.WeatherRequest
-	(*WeatherResponse)(nil), // 1: weather.WeatherResponse
+	(*WeatherRequest)(nil),   // 0: weather.WeatherRequest
+	(*WeatherResponse)(nil),  // 1: weather.WeatherResponse
+	(*ForecastRequest)(nil),  // 2: weather.ForecastRequest
+	(*DailyForecast)(nil),    // 3: weather.DailyForecast
+	(*HourlyForecast)(nil),   // 4: weather.HourlyForecast
+	(*ForecastResponse)(nil), // 5: weather.ForecastResponse
 }
 var file_weather_proto_depIdxs = []int32{
-	0, // 0: weather.WeatherService.GetWeather:input_type -> weather.WeatherRequest
-	1, // 1: weather.WeatherService.GetWeather:output_type -> weather.WeatherResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3, // 0: weather.ForecastResponse.daily:type_name -> weather.DailyForecast
+	4, // 1: weather.ForecastResponse.hourly:type_name -> weather.HourlyForecast
+	0, // 2: weather.WeatherService.GetWeather:input_type -> weather.WeatherRequest
+	2, // 3: weather.WeatherService.GetForecast:input_type -> weather.ForecastRequest
+	1, // 4: weather.WeatherService.GetWeather:output_type -> weather.WeatherResponse
+	5, // 5: weather.WeatherService.GetForecast:output_type -> weather.ForecastResponse
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_weather_proto_init() }
@@ -187,7 +470,7 @@ func file_weather_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_weather_proto_rawDesc), len(file_weather_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},